@@ -2,48 +2,35 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Audita el proyecto usando las skills definidas en Agent.md",
 	Long: `Lee el archivo Agent.md para identificar las skills activas y 
-valida que el código cumpla con las reglas definidas en ellas.`,
+valida que el código cumpla con las reglas definidas en ellas.
+
+Si el proyecto tiene .kolyn.json también verifica sus "required_skills" y aplica sus ajustes
+de "check" (skills omitidas con "skip" y archivo de variables con "env_file").`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runCheck(cmd.Context())
 	},
 }
 
-// SkillFrontmatter define la estructura del Frontmatter en los Markdowns
-type SkillFrontmatter struct {
-	Name        string     `yaml:"name"`
-	Description string     `yaml:"description"`
-	Check       SkillCheck `yaml:"check"`
-}
-
-type SkillCheck struct {
-	RequiredDeps  []string `yaml:"required_deps"`
-	DepsExistAny  []string `yaml:"deps_exist_any"`
-	ForbiddenDeps []string `yaml:"forbidden_deps"`
-	FilesExist    []string `yaml:"files_exist"`
-	FilesExistAny []string `yaml:"files_exist_any"`
-	EnvVars       []string `yaml:"env_vars"`
-	FailMessage   string   `yaml:"fail_message"`
-}
-
 // PackageJSON estructura mínima para leer dependencias
 type PackageJSON struct {
 	Dependencies    map[string]string `json:"dependencies"`
@@ -53,6 +40,7 @@ type PackageJSON struct {
 type AgentContext struct {
 	ProjectType      string
 	ActiveSkillPaths []string
+	ActiveSkillIDs   []string // Alineado con ActiveSkillPaths; vacío si Agent.md no anota el ID
 }
 
 func runCheck(ctx context.Context) error {
@@ -63,45 +51,90 @@ func runCheck(ctx context.Context) error {
 	}
 
 	cwd, _ := os.Getwd()
-	agentPath := filepath.Join(cwd, "Agent.md")
+
+	// 1.1 .kolyn.json del proyecto: archivo de contexto, skills requeridas y ajustes de check
+	effectiveCfg, projectCfg, err := loadProjectLayer(globalCfg, cwd)
+	if err != nil {
+		return err
+	}
+	var checkCfg config.CheckSettings
+	if projectCfg != nil {
+		checkCfg = projectCfg.Check
+	}
+	// Se puede auditar desde un subdirectorio: las rutas de Agent.md y de check son relativas a la raíz
+	root := cwd
+	if projectCfg != nil {
+		root = projectCfg.Root
+	}
+	agentTarget := projectCfg.AgentTargets()[0]
+	agentPath := filepath.Join(root, filepath.FromSlash(agentTarget))
 
 	// 2. Leer Agent.md
 	if _, err := os.Stat(agentPath); os.IsNotExist(err) {
-		ui.YellowText.Println("⚠️  No se encontró Agent.md en este proyecto.")
+		ui.YellowText.Printf("⚠️  No se encontró %s en este proyecto.\n", agentTarget)
 		ui.Gray.Println("   Ejecuta 'kolyn init' para configurar el contexto.")
 		return nil
 	}
 
 	agentCtx, err := parseAgentContext(agentPath)
 	if err != nil {
-		return fmt.Errorf("error leyendo Agent.md: %w", err)
+		return fmt.Errorf("error leyendo %s: %w", agentTarget, err)
 	}
 
 	ui.ShowSection("🕵️  Kolyn Check")
+	if projectCfg != nil {
+		ui.PrintInfo(ui.GetText("using_local"))
+	}
+	warnStaleSources(effectiveCfg)
 	ui.Cyan.Printf("   🔍 Tipo: %s\n", agentCtx.ProjectType)
 	ui.Cyan.Printf("   📚 Skills Activos: %d\n\n", len(agentCtx.ActiveSkillPaths))
 
+	totalChecks := 0
+	passedChecks := 0
+	warnings := 0
+
+	// 2.1 Skills que .kolyn.json exige tener vendorizadas
+	if projectCfg != nil {
+		totalChecks, passedChecks, warnings = checkRequiredSkills(root, projectCfg.RequiredSkills, agentCtx)
+	}
+
 	if len(agentCtx.ActiveSkillPaths) == 0 {
-		ui.YellowText.Println("⚠️  No hay skills definidos en Agent.md para auditar.")
+		ui.YellowText.Printf("⚠️  No hay skills definidos en %s para auditar.\n", agentTarget)
+		if warnings > 0 {
+			return fmt.Errorf("se encontraron %d problemas en la auditoría", warnings)
+		}
 		return nil
 	}
 
 	// 3. Cargar package.json (si aplica)
-	pkg, _ := loadPackageJSON(cwd)
+	pkg, _ := loadPackageJSON(root)
 	if pkg == nil && (agentCtx.ProjectType == "nextjs" || agentCtx.ProjectType == "node") {
 		ui.PrintWarning("No se encontró package.json. Se omitirán chequeos de dependencias.")
 	}
 
-	totalChecks := 0
-	passedChecks := 0
-	warnings := 0
+	// Resolver de extends: padres por ID entre las skills vendorizadas y las listadas en Agent.md
+	skillPaths := make(map[string]string)
+	for i, id := range agentCtx.ActiveSkillIDs {
+		if id != "" {
+			skillPaths[id] = resolveSkillLink(root, agentCtx.ActiveSkillPaths[i])
+		}
+	}
+	var extended map[string]bool
+	if localSkills, err := loadAllLocalSkills(root); err == nil {
+		for _, s := range localSkills {
+			skillPaths[s.ID] = s.OriginalPath
+		}
+		extended = extendedSkillIDs(localSkills)
+	}
+	resolver := newSkillResolver(skillPaths)
+	seenChecks := make(map[string]bool)
 
 	ui.Separator()
 
 	// 4. Validar cada skill listado en Agent.md
-	for _, skillPath := range agentCtx.ActiveSkillPaths {
-		// Resolver path (~)
-		resolvedPath := resolveHomePath(skillPath)
+	for i, skillPath := range agentCtx.ActiveSkillPaths {
+		// Resolver path (~ o relativo a la raíz del proyecto)
+		resolvedPath := resolveSkillLink(root, skillPath)
 
 		// Verificar existencia
 		if _, err := os.Stat(resolvedPath); os.IsNotExist(err) {
@@ -112,16 +145,37 @@ func runCheck(ctx context.Context) error {
 		}
 
 		fm, err := parseSkillFrontmatter(resolvedPath)
+		if errors.Is(err, errNoFrontmatter) {
+			// Markdown simple sin reglas de check
+			continue
+		}
 		if err != nil {
-			// Si falla el frontmatter, tal vez es un md simple sin reglas, lo ignoramos silenciosamente
-			// o mostramos un warning debug? Mejor ignorar si no tiene frontmatter válido.
+			ui.PrintWarning("Frontmatter inválido en %s: %v", skillPath, err)
+			ui.Gray.Printf("  (Ejecuta 'kolyn skills lint %s' para ver el detalle)\n", skillPath)
+			continue
+		}
+
+		id := agentCtx.ActiveSkillIDs[i]
+		if id == "" {
+			id = fm.ID
+		}
+		if extended[id] {
+			// Sus reglas se evalúan (con overrides) dentro de la skill que la extiende
+			continue
+		}
+		if id != "" && slices.Contains(checkCfg.Skip, id) {
+			ui.Gray.Printf("⏭️  %s omitida (check.skip en %s)\n\n", id, config.ProjectConfigFile)
 			continue
 		}
+		resolved, err := resolver.resolve(id, fm)
+		if err != nil {
+			ui.PrintWarning("%s: %v", skillPath, err)
+			resolved = fm
+		}
 
-		// Si no tiene reglas de check, skip
-		rules := fm.Check
-		if len(rules.RequiredDeps) == 0 && len(rules.ForbiddenDeps) == 0 && len(rules.FilesExist) == 0 &&
-			len(rules.DepsExistAny) == 0 && len(rules.FilesExistAny) == 0 && len(rules.EnvVars) == 0 {
+		// Si no tiene reglas de check (o ya las evaluó otra skill), skip
+		rules := resolved.Check.withoutSeen(seenChecks)
+		if rules.isEmpty() {
 			continue
 		}
 
@@ -132,10 +186,16 @@ func runCheck(ctx context.Context) error {
 		}
 
 		// Obtener categoría del path para display
-		relDir := filepath.Dir(resolvedPath)
-		category := filepath.Base(relDir)
+		category := filepath.Base(filepath.Dir(resolvedPath))
+		if rel := skillRelPathFromLink(skillPath); strings.Contains(rel, "/") {
+			category = path.Dir(rel)
+		}
 
-		ui.WhiteText.Printf("📦 Evaluando: %s/%s\n", category, skillName)
+		if id != "" {
+			ui.WhiteText.Printf("📦 Evaluando: %s\n", id)
+		} else {
+			ui.WhiteText.Printf("📦 Evaluando: %s/%s\n", category, skillName)
+		}
 		skillPassed := true
 
 		// --- CHECKS ---
@@ -189,7 +249,7 @@ func runCheck(ctx context.Context) error {
 		// 4. Files Exist
 		for _, file := range rules.FilesExist {
 			totalChecks++
-			if _, err := os.Stat(filepath.Join(cwd, file)); os.IsNotExist(err) {
+			if _, err := os.Stat(filepath.Join(root, file)); os.IsNotExist(err) {
 				ui.PrintFail("  ❌ Falta archivo: %s", file)
 				skillPassed = false
 				warnings++
@@ -204,7 +264,7 @@ func runCheck(ctx context.Context) error {
 			totalChecks++
 			foundAny := false
 			for _, file := range rules.FilesExistAny {
-				if _, err := os.Stat(filepath.Join(cwd, file)); err == nil {
+				if _, err := os.Stat(filepath.Join(root, file)); err == nil {
 					foundAny = true
 					ui.PrintSuccess("  ✅ Archivo encontrado (any): %s", file)
 					passedChecks++
@@ -220,7 +280,11 @@ func runCheck(ctx context.Context) error {
 
 		// 6. Env Vars
 		if len(rules.EnvVars) > 0 {
-			envContent, _ := os.ReadFile(filepath.Join(cwd, ".env"))
+			envFile := ".env"
+			if checkCfg.EnvFile != "" {
+				envFile = checkCfg.EnvFile
+			}
+			envContent, _ := os.ReadFile(filepath.Join(root, filepath.FromSlash(envFile)))
 			envStr := string(envContent)
 
 			for _, v := range rules.EnvVars {
@@ -242,6 +306,12 @@ func runCheck(ctx context.Context) error {
 		fmt.Println()
 	}
 
+	// 5. Verificar drift contra .kolyn/skills.lock
+	lockChecks, lockPassed, lockWarnings := checkSkillsLock(root)
+	totalChecks += lockChecks
+	passedChecks += lockPassed
+	warnings += lockWarnings
+
 	ui.Separator()
 	fmt.Println(ui.GetText("audit_summary", totalChecks, passedChecks, warnings))
 
@@ -252,6 +322,82 @@ func runCheck(ctx context.Context) error {
 	return nil
 }
 
+// checkRequiredSkills verifica que las skills de required_skills estén activas en el proyecto
+// (listadas en Agent.md o vendorizadas en .kolyn/skills)
+func checkRequiredSkills(root string, required []string, agentCtx *AgentContext) (checks, passed, problems int) {
+	if len(required) == 0 {
+		return 0, 0, 0
+	}
+
+	active := make(map[string]bool)
+	for _, id := range agentCtx.ActiveSkillIDs {
+		active[id] = true
+	}
+	if localSkills, err := loadAllLocalSkills(root); err == nil {
+		for _, s := range localSkills {
+			active[s.ID] = true
+		}
+	}
+
+	ui.WhiteText.Printf("📌 Skills requeridas (%s)\n", config.ProjectConfigFile)
+	for _, id := range required {
+		checks++
+		if active[id] {
+			ui.PrintSuccess("  ✅ %s", id)
+			passed++
+			continue
+		}
+		ui.PrintFail("  ❌ Falta la skill requerida: %s", id)
+		problems++
+	}
+	if problems > 0 {
+		ui.Gray.Println("  (Ejecuta 'kolyn init' para vendorizar las que falten)")
+	}
+	fmt.Println()
+
+	return checks, passed, problems
+}
+
+// checkSkillsLock compara las skills vendorizadas contra .kolyn/skills.lock.
+// Un archivo bloqueado que falta cuenta como problema; una modificación local solo se reporta.
+func checkSkillsLock(root string) (checks, passed, problems int) {
+	lock, err := loadSkillsLock(root)
+	if err != nil {
+		ui.PrintFail("❌ %v", err)
+		return 1, 0, 1
+	}
+	if len(lock.Skills) == 0 {
+		return 0, 0, 0
+	}
+
+	ui.WhiteText.Printf("🔒 Verificando %s\n", skillsLockFile)
+
+	for _, locked := range lock.Skills {
+		checks++
+		hash, err := hashFile(filepath.Join(root, filepath.FromSlash(locked.LocalPath)))
+		if err != nil {
+			ui.PrintFail("  ❌ Skill registrada no encontrada: %s", locked.LocalPath)
+			problems++
+			continue
+		}
+		passed++
+		if hash != locked.localHash() {
+			ui.YellowText.Printf("  ⚠️  %s fue modificada localmente (difiere de %s)\n", locked.LocalPath, skillsLockFile)
+		}
+	}
+
+	if localSkills, err := loadAllLocalSkills(root); err == nil {
+		for _, s := range localSkills {
+			if lock.find(s.LocalPath) == nil {
+				ui.Gray.Printf("  ℹ️  %s no tiene registro en %s\n", s.LocalPath, skillsLockFile)
+			}
+		}
+	}
+	fmt.Println()
+
+	return checks, passed, problems
+}
+
 func parseAgentContext(path string) (*AgentContext, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -262,6 +408,7 @@ func parseAgentContext(path string) (*AgentContext, error) {
 	ctx := &AgentContext{
 		ProjectType:      "generic",
 		ActiveSkillPaths: []string{},
+		ActiveSkillIDs:   []string{},
 	}
 
 	scanner := bufio.NewScanner(file)
@@ -293,6 +440,7 @@ func parseAgentContext(path string) (*AgentContext, error) {
 			matches := linkRegex.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				ctx.ActiveSkillPaths = append(ctx.ActiveSkillPaths, matches[1])
+				ctx.ActiveSkillIDs = append(ctx.ActiveSkillIDs, parseAgentSkillID(line))
 			}
 		}
 	}
@@ -300,7 +448,14 @@ func parseAgentContext(path string) (*AgentContext, error) {
 	return ctx, nil
 }
 
+// resolveHomePath expande los links de Agent.md que empiezan con "~/". Los de ~/.kolyn (versiones
+// anteriores) apuntan al directorio de datos de Kolyn aunque se haya reubicado con KOLYN_HOME o XDG.
 func resolveHomePath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/.kolyn/"); ok {
+		if paths, err := config.GetPaths(); err == nil {
+			return filepath.Join(paths.DataDir, filepath.FromSlash(rest))
+		}
+	}
 	if strings.HasPrefix(path, "~/") {
 		home, _ := os.UserHomeDir()
 		return filepath.Join(home, path[2:])
@@ -308,28 +463,14 @@ func resolveHomePath(path string) string {
 	return path
 }
 
-func parseSkillFrontmatter(path string) (*SkillFrontmatter, error) {
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	if !bytes.HasPrefix(content, []byte("---")) {
-		return nil, fmt.Errorf("no frontmatter")
-	}
-
-	parts := bytes.SplitN(content, []byte("---"), 3)
-	if len(parts) < 3 {
-		return nil, fmt.Errorf("frontmatter mal formado")
-	}
-
-	yamlContent := parts[1]
-	var fm SkillFrontmatter
-	if err := yaml.Unmarshal(yamlContent, &fm); err != nil {
-		return nil, err
+// resolveSkillLink resuelve un link de Agent.md: "~/" como resolveHomePath y las rutas
+// relativas contra la raíz del proyecto
+func resolveSkillLink(root, link string) string {
+	resolved := resolveHomePath(link)
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(root, filepath.FromSlash(resolved))
 	}
-
-	return &fm, nil
+	return resolved
 }
 
 func loadPackageJSON(path string) (*PackageJSON, error) {
@@ -346,6 +487,21 @@ func loadPackageJSON(path string) (*PackageJSON, error) {
 }
 
 func hasDependency(pkg *PackageJSON, dep string) bool {
+	if pattern, ok := depPattern(dep); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false // kolyn skills lint reporta la regex inválida
+		}
+		for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+			for name := range deps {
+				if re.MatchString(name) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
 	if _, ok := pkg.Dependencies[dep]; ok {
 		return true
 	}
@@ -354,3 +510,11 @@ func hasDependency(pkg *PackageJSON, dep string) bool {
 	}
 	return false
 }
+
+// depPattern devuelve la regex de una dependencia escrita como /patrón/ (ej. /^@radix-ui\//)
+func depPattern(dep string) (string, bool) {
+	if len(dep) < 2 || !strings.HasPrefix(dep, "/") || !strings.HasSuffix(dep, "/") {
+		return "", false
+	}
+	return dep[1 : len(dep)-1], true
+}
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
@@ -12,7 +15,15 @@ import (
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Gestiona la configuración global de Kolyn",
-	Long:  `Permite ver y modificar la configuración global almacenada en ~/.kolyn/config.json`,
+	Long: `Permite ver y modificar la configuración global almacenada en ~/.kolyn/config.json.
+
+Las claves usan puntos; los sources se eligen por nombre o por índice:
+  kolyn config get language
+  kolyn config set sources_max_age 3d
+  kolyn config set skills_sources.team-skills.ref v1.2.0
+  kolyn config set skills_sources.0.enabled false
+
+Los valores se interpretan como JSON (números, true/false, listas) y si no, como texto.`,
 }
 
 var configInitCmd = &cobra.Command{
@@ -24,15 +35,148 @@ var configInitCmd = &cobra.Command{
 	},
 }
 
+var configGetCmd = &cobra.Command{
+	Use:           "get <clave>",
+	Short:         "Muestra el valor de una clave de la configuración global",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigGet(args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:           "set <clave> <valor>",
+	Short:         "Cambia el valor de una clave de la configuración global",
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSet(args[0], args[1])
+	},
+}
+
+var configListJSON bool
+
+var configListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "Lista todas las claves de la configuración global",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigList(configListJSON)
+	},
+}
+
+var configPathsCmd = &cobra.Command{
+	Use:   "paths",
+	Short: "Muestra los directorios que usa Kolyn (config, datos y caché)",
+	Long: `Por defecto todo vive en ~/.kolyn. KOLYN_HOME mueve todo a otro directorio; sin ~/.kolyn,
+XDG_CONFIG_HOME, XDG_DATA_HOME y XDG_CACHE_HOME separan config, datos y caché en <dir>/kolyn.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigPaths()
+	},
+}
+
+var configSourcesCmd = &cobra.Command{
+	Use:   "sources",
+	Short: "Gestiona los sources de skills de la configuración global",
+}
+
+var configSourcesListJSON bool
+
+var configSourcesListCmd = &cobra.Command{
+	Use:           "list",
+	Short:         "Lista los sources de skills configurados",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSourcesList(configSourcesListJSON)
+	},
+}
+
+// configSourcesAddOpts son los flags de kolyn config sources add
+var configSourcesAddOpts struct {
+	Name      string
+	Ref       string
+	Subdir    string
+	Priority  int
+	Disabled  bool
+	Auth      string
+	Mode      string
+	Sparse    []string
+	FullClone bool
+}
+
+var configSourcesAddCmd = &cobra.Command{
+	Use:   "add <url[#ref]>",
+	Short: "Agrega un source de skills (repo git, directorio local o archivo)",
+	Long: `Agrega un source a skills_sources en ~/.kolyn/config.json.
+La URL acepta un ref con '#' (ej. https://github.com/org/skills#v1.2.0) o con --ref.
+Ejecuta 'kolyn sync' después para descargarlo.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSourcesAdd(args[0])
+	},
+}
+
+var configSourcesRemoveYes bool
+
+var configSourcesRemoveCmd = &cobra.Command{
+	Use:           "remove <nombre|url>",
+	Short:         "Quita un source de skills y, si se confirma, su copia en ~/.kolyn/sources",
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSourcesRemove(args[0], configSourcesRemoveYes)
+	},
+}
+
 func init() {
+	configListCmd.Flags().BoolVar(&configListJSON, "json", false, "Imprime la configuración completa en JSON")
+
+	configSourcesListCmd.Flags().BoolVar(&configSourcesListJSON, "json", false, "Imprime los sources en JSON")
+
+	addFlags := configSourcesAddCmd.Flags()
+	addFlags.StringVar(&configSourcesAddOpts.Name, "name", "", "Nombre del source (por defecto se deriva de la URL)")
+	addFlags.StringVar(&configSourcesAddOpts.Ref, "ref", "", "Tag, branch o commit a fijar")
+	addFlags.StringVar(&configSourcesAddOpts.Subdir, "subdir", "", "Subdirectorio del repo que contiene las skills")
+	addFlags.IntVar(&configSourcesAddOpts.Priority, "priority", 0, "Prioridad ante skills con el mismo ID (mayor gana)")
+	addFlags.BoolVar(&configSourcesAddOpts.Disabled, "disabled", false, "Agrega el source deshabilitado")
+	addFlags.StringVar(&configSourcesAddOpts.Auth, "auth", "", "Pista de autenticación que se muestra si el acceso falla")
+	addFlags.StringVar(&configSourcesAddOpts.Mode, "mode", "", "Para directorios locales: link (por defecto) o copy")
+	addFlags.StringSliceVar(&configSourcesAddOpts.Sparse, "sparse", nil, "Directorios a descargar (sparse checkout)")
+	addFlags.BoolVar(&configSourcesAddOpts.FullClone, "full-clone", false, "Clona el historial completo en lugar de un clon superficial")
+
+	configSourcesRemoveCmd.Flags().BoolVarP(&configSourcesRemoveYes, "yes", "y", false, "Borra la copia local sin preguntar")
+
+	configSourcesCmd.AddCommand(configSourcesListCmd)
+	configSourcesCmd.AddCommand(configSourcesAddCmd)
+	configSourcesCmd.AddCommand(configSourcesRemoveCmd)
+
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configPathsCmd)
+	configCmd.AddCommand(configSourcesCmd)
 }
 
 func runConfigInit(ctx context.Context) error {
 	ui.ShowSection("⚙️  Kolyn Global Config")
 
 	ui.PrintStep("Bienvenido al asistente de configuración global.")
-	ui.Gray.Println("Esta configuración se guardará en ~/.kolyn/config.json y se usará por defecto en todos tus proyectos.")
+	path, err := config.GetGlobalConfigPath()
+	if err != nil {
+		return err
+	}
+	ui.Gray.Printf("Esta configuración se guardará en %s y se usará por defecto en todos tus proyectos.\n", config.DisplayPath(path))
 	fmt.Println()
 
 	// 1. Idioma
@@ -43,13 +187,13 @@ func runConfigInit(ctx context.Context) error {
 	ui.PrintQuestion(ui.GetText("skills_repo_prompt"))
 	repoURL := ui.ReadInput("> ")
 
-	var sources []string
+	var sources []config.SkillSource
 	if repoURL != "" {
-		sources = []string{repoURL}
+		sources = []config.SkillSource{config.ParseSkillSource(repoURL)}
 	} else {
 		// No default repo provided
-		sources = []string{}
-		ui.PrintInfo("No se configuró repositorio de skills. Usa 'kolyn config' para agregarlo después.")
+		sources = []config.SkillSource{}
+		ui.PrintInfo("No se configuró repositorio de skills. Usa 'kolyn config sources add <url>' para agregarlo después.")
 	}
 
 	// 3. Guardar
@@ -62,8 +206,277 @@ func runConfigInit(ctx context.Context) error {
 		return fmt.Errorf("error guardando configuración: %w", err)
 	}
 
-	ui.PrintSuccess(ui.GetText("global_created"))
+	ui.PrintSuccess(ui.GetText("global_created", config.DisplayPath(path)))
 	ui.Gray.Println("Ahora puedes ejecutar 'kolyn sync' en cualquier proyecto para descargar estas skills.")
 
 	return nil
 }
+
+// loadOrNewGlobalConfig carga la configuración global (sin validar, para poder corregirla) o una vacía si todavía no existe
+func loadOrNewGlobalConfig() (*config.GlobalConfig, error) {
+	cfg, err := config.ReadGlobalConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &config.GlobalConfig{Language: ui.CurrentLanguage, SkillsSources: []config.SkillSource{}}
+	}
+	return cfg, nil
+}
+
+// currentProjectRoot es el directorio del proyecto actual: la raíz con .kolyn.json más cercana
+// subiendo desde el directorio actual (así los comandos funcionan desde subcarpetas) o el propio
+// directorio actual si no hay ninguno
+func currentProjectRoot() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("error obteniendo directorio actual: %w", err)
+	}
+	if root := config.FindProjectRoot(cwd); root != "" {
+		return root, nil
+	}
+	return cwd, nil
+}
+
+// loadProjectLayer busca el .kolyn.json más cercano a dir y lo aplica sobre la configuración
+// global. Devuelve la configuración efectiva (nunca nil) y la del proyecto (nil si no hay).
+func loadProjectLayer(globalCfg *config.GlobalConfig, dir string) (*config.GlobalConfig, *config.ProjectConfig, error) {
+	projectCfg, root, err := config.FindProjectConfig(dir)
+	if err != nil {
+		return globalCfg.WithProject(nil), nil, fmt.Errorf("%s: %w", config.GetProjectConfigPath(root), err)
+	}
+	return globalCfg.WithProject(projectCfg), projectCfg, nil
+}
+
+func runConfigGet(key string) error {
+	cfg, err := loadOrNewGlobalConfig()
+	if err != nil {
+		return err
+	}
+	value, err := cfg.GetValue(key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(config.FormatValue(value))
+	return nil
+}
+
+func runConfigSet(key, value string) error {
+	cfg, err := loadOrNewGlobalConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.SetValue(key, value); err != nil {
+		return err
+	}
+	if err := config.SaveGlobalConfig(cfg); err != nil {
+		return fmt.Errorf("error guardando configuración: %w", err)
+	}
+
+	updated, _ := cfg.GetValue(key)
+	ui.PrintSuccess("%s = %s", key, config.FormatValue(updated))
+	return nil
+}
+
+func runConfigList(asJSON bool) error {
+	cfg, err := loadOrNewGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	pairs, err := cfg.Keys()
+	if err != nil {
+		return err
+	}
+	for _, kv := range pairs {
+		ui.WhiteText.Print(kv[0])
+		fmt.Printf(" = %s\n", kv[1])
+	}
+	return nil
+}
+
+func runConfigPaths() error {
+	paths, err := config.GetPaths()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range [][2]string{
+		{"config", paths.ConfigFile()},
+		{"skills", paths.SkillsDir()},
+		{"sources", paths.SourcesDir()},
+		{"templates", paths.TemplatesDir()},
+		{"services", paths.ServicesDir()},
+		{"cache", paths.CacheDir},
+	} {
+		ui.WhiteText.Printf("%-10s", p[0])
+		fmt.Printf(" %s\n", p[1])
+	}
+	if home := os.Getenv(config.EnvKolynHome); home != "" {
+		ui.Gray.Printf("(%s=%s)\n", config.EnvKolynHome, home)
+	}
+	return nil
+}
+
+func runConfigSourcesList(asJSON bool) error {
+	cfg, err := loadOrNewGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(cfg.SkillsSources, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(cfg.SkillsSources) == 0 {
+		ui.PrintInfo("No hay sources configurados. Usa 'kolyn config sources add <url>' para agregar uno.")
+		return nil
+	}
+
+	width := len("NOMBRE")
+	for _, s := range cfg.SkillsSources {
+		width = max(width, len(s.Name))
+	}
+	fmt.Printf("%-*s  %-7s  %-12s  %-9s  %-13s  %s\n", width, "NOMBRE", "TIPO", "REF", "PRIORIDAD", "ESTADO", "URL")
+	for _, s := range cfg.SkillsSources {
+		ref := s.Ref
+		if ref == "" {
+			ref = "-"
+		}
+		status := ui.GreenText
+		state := "habilitado"
+		if !s.IsEnabled() {
+			status = ui.Gray
+			state = "deshabilitado"
+		}
+		fmt.Printf("%-*s  %-7s  %-12s  %-9d  ", width, s.Name, s.Kind(), ref, s.Priority)
+		status.Printf("%-13s", state)
+		fmt.Printf("  %s\n", s.URL)
+	}
+	return nil
+}
+
+func runConfigSourcesAdd(rawURL string) error {
+	cfg, err := loadOrNewGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	opts := configSourcesAddOpts
+	source := config.ParseSkillSource(rawURL)
+	if opts.Name != "" {
+		source.Name = opts.Name
+	}
+	if opts.Ref != "" {
+		source.Ref = opts.Ref
+	}
+	source.Subdir = opts.Subdir
+	source.Priority = opts.Priority
+	source.Auth = opts.Auth
+	source.Mode = opts.Mode
+	source.Sparse = opts.Sparse
+	source.FullClone = opts.FullClone
+	if opts.Disabled {
+		enabled := false
+		source.Enabled = &enabled
+	}
+
+	for _, existing := range cfg.SkillsSources {
+		if existing.URL == source.URL {
+			return fmt.Errorf("el source '%s' ya usa la URL %s (cámbialo con 'kolyn config set skills_sources.%s.<campo>')", existing.Name, source.URL, existing.Name)
+		}
+		if existing.Name == source.Name {
+			return fmt.Errorf("ya existe un source llamado '%s' (usa --name para elegir otro)", source.Name)
+		}
+	}
+
+	cfg.SkillsSources = append(cfg.SkillsSources, source)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := config.SaveGlobalConfig(cfg); err != nil {
+		return fmt.Errorf("error guardando configuración: %w", err)
+	}
+
+	ui.PrintSuccess("Source '%s' agregado (%s)", source.Name, source.Kind())
+	ui.Gray.Println("Ejecuta 'kolyn sync' para descargar sus skills.")
+	return nil
+}
+
+func runConfigSourcesRemove(target string, yes bool) error {
+	cfg, err := config.ReadGlobalConfig()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("no hay configuración global; ejecuta 'kolyn config init'")
+	}
+
+	index := -1
+	for i, s := range cfg.SkillsSources {
+		if s.Name == target || s.URL == target || s.String() == target {
+			index = i
+			break
+		}
+	}
+	if n, err := strconv.Atoi(target); err == nil && index < 0 && n >= 0 && n < len(cfg.SkillsSources) {
+		index = n
+	}
+	if index < 0 {
+		return fmt.Errorf("no existe el source '%s' (ver 'kolyn config sources list')", target)
+	}
+
+	source := cfg.SkillsSources[index]
+	cfg.SkillsSources = append(cfg.SkillsSources[:index], cfg.SkillsSources[index+1:]...)
+	if err := config.SaveGlobalConfig(cfg); err != nil {
+		return fmt.Errorf("error guardando configuración: %w", err)
+	}
+	ui.PrintSuccess("Source '%s' eliminado de la configuración", source.Name)
+
+	paths, err := config.GetPaths()
+	if err != nil {
+		return err
+	}
+	// El nombre viene de config.json: no se borra nada fuera de sources/
+	sourceDir, err := paths.SourceDir(source.Name)
+	if err != nil {
+		ui.PrintWarning("No se borra la copia local: %v", err)
+	} else if _, err := os.Lstat(sourceDir); err == nil {
+		if yes || ui.AskYesNo(fmt.Sprintf("¿Borrar también su copia local en %s?", sourceDir)) {
+			// En un source enlazado (mode link) solo se borra el enlace, no el directorio original
+			if err := os.RemoveAll(sourceDir); err != nil {
+				return fmt.Errorf("error borrando %s: %w", sourceDir, err)
+			}
+			ui.PrintSuccess("Copia local borrada")
+		} else {
+			ui.Gray.Printf("Se conserva %s\n", sourceDir)
+		}
+	}
+
+	if state, err := loadSyncState(); err == nil {
+		if _, ok := state.Sources[source.Name]; ok {
+			delete(state.Sources, source.Name)
+			if err := saveSyncState(state); err != nil {
+				ui.PrintWarning("No se pudo actualizar el estado de sincronización: %v", err)
+			}
+		}
+	}
+	if err := invalidateSkillIndex(); err != nil {
+		ui.PrintWarning("No se pudo invalidar el índice de skills: %v", err)
+	}
+	return nil
+}
@@ -5,22 +5,67 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type GlobalConfig struct {
-	Language      string   `json:"language"`       // "es" or "en"
-	SkillsSources []string `json:"skills_sources"` // Global default sources
+	Language      string        `json:"language"`                  // "es" or "en"
+	SkillsSources []SkillSource `json:"skills_sources"`            // Global default sources
+	MaxSourceAge  string        `json:"sources_max_age,omitempty"` // Ej. "7d", "12h"; "off" desactiva el aviso
+}
+
+// DefaultSourcesMaxAge es la antigüedad a partir de la cual init y check avisan que un source está desactualizado
+const DefaultSourcesMaxAge = 7 * 24 * time.Hour
+
+// SourcesMaxAge interpreta sources_max_age; 0 significa que no se avisa
+func (c *GlobalConfig) SourcesMaxAge() (time.Duration, error) {
+	value := strings.TrimSpace(c.MaxSourceAge)
+	switch value {
+	case "":
+		return DefaultSourcesMaxAge, nil
+	case "0", "off":
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("sources_max_age inválido '%s' (usa ej. 7d, 12h u off)", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("sources_max_age inválido '%s' (usa ej. 7d, 12h u off)", value)
+	}
+	return d, nil
 }
 
 func GetGlobalConfigPath() (string, error) {
-	home, err := os.UserHomeDir()
+	paths, err := GetPaths()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".kolyn", "config.json"), nil
+	return paths.ConfigFile(), nil
 }
 
+// LoadGlobalConfig lee y valida config.json; devuelve nil si todavía no existe
 func LoadGlobalConfig() (*GlobalConfig, error) {
+	cfg, err := ReadGlobalConfig()
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	if err := cfg.Validate(); err != nil {
+		path, _ := GetGlobalConfigPath()
+		return nil, fmt.Errorf("%s: %w (corrígelo con 'kolyn config set' o 'kolyn config sources remove')", path, err)
+	}
+	return cfg, nil
+}
+
+// ReadGlobalConfig lee config.json sin validarlo, para que kolyn config pueda corregirlo
+func ReadGlobalConfig() (*GlobalConfig, error) {
 	path, err := GetGlobalConfigPath()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,292 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Las claves de config.json se expresan con puntos: "language", "sources_max_age",
+// "skills_sources.<name o índice>.ref". Se trabaja sobre la representación JSON para que
+// cualquier campo nuevo de GlobalConfig o SkillSource sea accesible sin código adicional.
+
+// GetValue devuelve el valor de una clave (string, número, bool, objeto o lista)
+func (c *GlobalConfig) GetValue(key string) (interface{}, error) {
+	root, err := toJSONMap(c)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := resolveKey(root, key)
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	if value, ok := last.get(); ok {
+		return value, nil
+	}
+	// Campo omitido en el JSON (omitempty): su valor es el cero del tipo, pasado por JSON para
+	// devolver los mismos tipos que un campo presente (float64, []interface{}, ...)
+	data, err := json.Marshal(reflect.Zero(last.typ).Interface())
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// SetValue asigna una clave a partir de texto. Las claves de tipo string guardan el texto tal
+// cual (ej. un ref "20240101"); las demás lo interpretan como JSON (número, bool, lista, objeto)
+// y, si no lo es, como string. El resultado se valida antes de aplicarlo.
+func (c *GlobalConfig) SetValue(key, raw string) error {
+	root, err := toJSONMap(c)
+	if err != nil {
+		return err
+	}
+	path, err := resolveKey(root, key)
+	if err != nil {
+		return err
+	}
+	last := path[len(path)-1]
+
+	var value interface{} = raw
+	if derefType(last.typ).Kind() != reflect.String {
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+	}
+	last.set(value)
+
+	data, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	var updated GlobalConfig
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return fmt.Errorf("valor inválido para '%s': %w", key, err)
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	*c = updated
+	return nil
+}
+
+// keyStep es un segmento resuelto de una clave: el contenedor JSON, el campo o índice y el tipo Go esperado
+type keyStep struct {
+	container interface{} // map[string]interface{} o []interface{}
+	field     string
+	index     int
+	typ       reflect.Type
+}
+
+func (s keyStep) get() (interface{}, bool) {
+	switch c := s.container.(type) {
+	case map[string]interface{}:
+		v, ok := c[s.field]
+		return v, ok
+	case []interface{}:
+		return c[s.index], true
+	}
+	return nil, false
+}
+
+func (s keyStep) set(value interface{}) {
+	switch c := s.container.(type) {
+	case map[string]interface{}:
+		c[s.field] = value
+	case []interface{}:
+		c[s.index] = value
+	}
+}
+
+// resolveKey recorre la clave sobre el JSON y el modelo a la vez. Los campos deben existir en el
+// modelo (aunque falten en el JSON por omitempty); los elementos de una lista se eligen por
+// "name" (que puede contener puntos, ej. github.com-org-skills) o por índice.
+func resolveKey(root map[string]interface{}, key string) ([]keyStep, error) {
+	segments := strings.Split(key, ".")
+	if strings.TrimSpace(key) == "" {
+		return nil, fmt.Errorf("clave vacía")
+	}
+
+	var path []keyStep
+	var current interface{} = root
+	typ := reflect.TypeOf(GlobalConfig{})
+	for i := 0; i < len(segments); {
+		if len(path) > 0 {
+			last := path[len(path)-1]
+			value, ok := last.get()
+			if !ok {
+				return nil, fmt.Errorf("clave '%s': '%s' no tiene valor", key, strings.Join(segments[:i], "."))
+			}
+			current = value
+		}
+
+		switch container := current.(type) {
+		case map[string]interface{}:
+			field, ok := jsonField(typ, segments[i])
+			if !ok {
+				return nil, fmt.Errorf("clave desconocida '%s'", key)
+			}
+			path = append(path, keyStep{container: container, field: segments[i], typ: field})
+			typ = derefType(field)
+			i++
+
+		case []interface{}:
+			step := keyStep{container: container, index: -1, typ: typ.Elem()}
+			for j := len(segments); j > i && step.index < 0; j-- {
+				name := strings.Join(segments[i:j], ".")
+				for idx, item := range container {
+					if m, ok := item.(map[string]interface{}); ok && m["name"] == name {
+						step.index = idx
+						i = j
+						break
+					}
+				}
+			}
+			if step.index < 0 {
+				idx, err := strconv.Atoi(segments[i])
+				if err != nil || idx < 0 || idx >= len(container) {
+					return nil, fmt.Errorf("clave '%s': no existe el elemento '%s'", key, segments[i])
+				}
+				step.index = idx
+				i++
+			}
+			path = append(path, step)
+			typ = derefType(typ.Elem())
+
+		default:
+			return nil, fmt.Errorf("clave '%s': '%s' no tiene campos", key, strings.Join(segments[:i], "."))
+		}
+	}
+	return path, nil
+}
+
+// jsonField busca en un struct el campo con ese nombre JSON y devuelve su tipo
+func jsonField(typ reflect.Type, name string) (reflect.Type, bool) {
+	if typ.Kind() != reflect.Struct {
+		return nil, false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == name {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// Validate revisa los valores que el tipo JSON no alcanza a validar
+func (c *GlobalConfig) Validate() error {
+	if c.Language != "" && c.Language != "es" && c.Language != "en" {
+		return fmt.Errorf("language inválido '%s' (usa es o en)", c.Language)
+	}
+	if _, err := c.SourcesMaxAge(); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	for _, s := range c.SkillsSources {
+		if err := ValidateSourceName(s.Name); err != nil {
+			return err
+		}
+		if s.URL == "" {
+			return fmt.Errorf("skills_sources: '%s' no tiene url", s.Name)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("skills_sources: el nombre '%s' está repetido", s.Name)
+		}
+		names[s.Name] = true
+		if s.Mode != "" && s.Mode != SourceModeLink && s.Mode != SourceModeCopy {
+			return fmt.Errorf("skills_sources.%s.mode inválido '%s' (usa %s o %s)", s.Name, s.Mode, SourceModeLink, SourceModeCopy)
+		}
+	}
+	return nil
+}
+
+// Keys devuelve todas las claves con valor escalar y su valor, ordenadas (para kolyn config list)
+func (c *GlobalConfig) Keys() ([][2]string, error) {
+	root, err := toJSONMap(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs [][2]string
+	var walk func(prefix string, v interface{})
+	walk = func(prefix string, v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				walk(joinKey(prefix, k), t[k])
+			}
+		case []interface{}:
+			if len(t) == 0 {
+				pairs = append(pairs, [2]string{prefix, "[]"})
+			}
+			for i, item := range t {
+				segment := strconv.Itoa(i)
+				// Los sources se identifican por nombre, igual que en get/set
+				if m, ok := item.(map[string]interface{}); ok {
+					if name, ok := m["name"].(string); ok && name != "" {
+						segment = name
+					}
+				}
+				walk(joinKey(prefix, segment), item)
+			}
+		default:
+			pairs = append(pairs, [2]string{prefix, FormatValue(t)})
+		}
+	}
+	walk("", root)
+	return pairs, nil
+}
+
+// FormatValue muestra un valor de config: los strings tal cual y el resto como JSON
+func FormatValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func toJSONMap(c *GlobalConfig) (map[string]interface{}, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func joinKey(prefix, segment string) string {
+	if prefix == "" {
+		return segment
+	}
+	return prefix + "." + segment
+}
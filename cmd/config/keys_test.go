@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestConfig() *GlobalConfig {
+	disabled := false
+	return &GlobalConfig{
+		Language: "es",
+		SkillsSources: []SkillSource{
+			{Name: "github.com-org-skills", URL: "git@github.com:org/skills.git", Ref: "v1.0.0", Priority: 10},
+			{Name: "personal", URL: "~/dev/skills", Enabled: &disabled},
+			{Name: "1", URL: "/tmp/uno"},
+		},
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    interface{}
+		wantErr bool
+	}{
+		{key: "language", want: "es"},
+		{key: "sources_max_age", want: ""},
+		{key: "skills_sources.github.com-org-skills.ref", want: "v1.0.0"},
+		{key: "skills_sources.github.com-org-skills.priority", want: float64(10)},
+		{key: "skills_sources.personal.enabled", want: false},
+		{key: "skills_sources.personal.priority", want: float64(0)}, // Omitido: mismo tipo que con valor
+		{key: "skills_sources.0.url", want: "git@github.com:org/skills.git"},
+		{key: "skills_sources.1.url", want: "/tmp/uno"}, // El name gana sobre el índice
+		{key: "skills_sources.2.name", want: "1"},
+		{key: "skills_sources.personal.sparse", want: nil},
+		{key: "skills_sources.0.enabled", want: nil},
+		{key: "skills_sources.0.full_clone", want: false},
+		{key: "", wantErr: true},
+		{key: "idioma", wantErr: true},
+		{key: "skills_sources.personal.nope", wantErr: true},
+		{key: "skills_sources.nope.ref", wantErr: true},
+		{key: "skills_sources.9.ref", wantErr: true},
+		{key: "skills_sources.-1.ref", wantErr: true},
+		{key: "language.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := newTestConfig().GetValue(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		raw     string
+		check   func(c *GlobalConfig) interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:  "ref numérico se guarda como texto",
+			key:   "skills_sources.0.ref",
+			raw:   "20240101",
+			check: func(c *GlobalConfig) interface{} { return c.SkillsSources[0].Ref },
+			want:  "20240101",
+		},
+		{
+			name:  "ref con comillas se guarda tal cual",
+			key:   "skills_sources.github.com-org-skills.ref",
+			raw:   `"v2"`,
+			check: func(c *GlobalConfig) interface{} { return c.SkillsSources[0].Ref },
+			want:  `"v2"`,
+		},
+		{
+			name:  "priority como número",
+			key:   "skills_sources.personal.priority",
+			raw:   "5",
+			check: func(c *GlobalConfig) interface{} { return c.SkillsSources[1].Priority },
+			want:  5,
+		},
+		{
+			name:  "enabled como bool",
+			key:   "skills_sources.personal.enabled",
+			raw:   "true",
+			check: func(c *GlobalConfig) interface{} { return c.SkillsSources[1].IsEnabled() },
+			want:  true,
+		},
+		{
+			name:  "sparse como lista",
+			key:   "skills_sources.0.sparse",
+			raw:   `["backend","profiles"]`,
+			check: func(c *GlobalConfig) interface{} { return c.SkillsSources[0].Sparse },
+			want:  []string{"backend", "profiles"},
+		},
+		{
+			name:  "name con puntos",
+			key:   "skills_sources.personal.name",
+			raw:   "mis.skills",
+			check: func(c *GlobalConfig) interface{} { return c.SkillsSources[1].Name },
+			want:  "mis.skills",
+		},
+		{
+			name:  "sources_max_age",
+			key:   "sources_max_age",
+			raw:   "3d",
+			check: func(c *GlobalConfig) interface{} { return c.MaxSourceAge },
+			want:  "3d",
+		},
+		{name: "name con ruta", key: "skills_sources.0.name", raw: "../../tmp/evil", wantErr: true},
+		{name: "name absoluto", key: "skills_sources.0.name", raw: "/tmp/evil", wantErr: true},
+		{name: "name repetido", key: "skills_sources.0.name", raw: "personal", wantErr: true},
+		{name: "mode inválido", key: "skills_sources.personal.mode", raw: "move", wantErr: true},
+		{name: "language inválido", key: "language", raw: "fr", wantErr: true},
+		{name: "priority no numérico", key: "skills_sources.0.priority", raw: "alta", wantErr: true},
+		{name: "clave desconocida", key: "skills_sources.0.branch", raw: "main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			err := cfg.SetValue(tt.key, tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !reflect.DeepEqual(cfg, newTestConfig()) {
+					t.Errorf("un set inválido modificó la configuración: %+v", cfg)
+				}
+				return
+			}
+			if got := tt.check(cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetValueThenGetByNewName(t *testing.T) {
+	cfg := newTestConfig()
+	if err := cfg.SetValue("skills_sources.personal.name", "github.com-yo-skills"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cfg.GetValue("skills_sources.github.com-yo-skills.url")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "~/dev/skills" {
+		t.Errorf("got %#v", got)
+	}
+}
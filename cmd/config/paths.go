@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Directorios de Kolyn. Por defecto todo vive en ~/.kolyn; se puede mover con:
+//   - KOLYN_HOME: todo en ese directorio, con el mismo layout que ~/.kolyn (CI, pruebas).
+//   - XDG_CONFIG_HOME, XDG_DATA_HOME y XDG_CACHE_HOME: config.json, los datos (skills, sources,
+//     templates, servicios y estado de sync) y la caché por separado, en <dir>/kolyn.
+//
+// Si ~/.kolyn ya existe se sigue usando aunque haya variables XDG, para no dejar atrás la
+// configuración y los sources de una instalación anterior.
+
+// EnvKolynHome es la variable de entorno que reubica todos los directorios de Kolyn
+const EnvKolynHome = "KOLYN_HOME"
+
+const (
+	legacyDirName = ".kolyn"
+	xdgDirName    = "kolyn"
+)
+
+// Paths son los directorios que usa Kolyn fuera de los proyectos
+type Paths struct {
+	ConfigDir string // config.json
+	DataDir   string // skills/, sources/, worktrees/, templates/, services/ y sync-state.json
+	CacheDir  string // Índice de skills
+}
+
+// GetPaths resuelve los directorios según KOLYN_HOME, ~/.kolyn y las variables XDG
+func GetPaths() (Paths, error) {
+	if home := os.Getenv(EnvKolynHome); home != "" {
+		dir, err := filepath.Abs(home)
+		if err != nil {
+			return Paths{}, fmt.Errorf("%s inválido '%s': %w", EnvKolynHome, home, err)
+		}
+		return Paths{ConfigDir: dir, DataDir: dir, CacheDir: filepath.Join(dir, "cache")}, nil
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return Paths{}, fmt.Errorf("error obteniendo directorio home: %w", err)
+	}
+	legacy := filepath.Join(userHome, legacyDirName)
+	paths := Paths{ConfigDir: legacy, DataDir: legacy, CacheDir: filepath.Join(legacy, "cache")}
+	if _, err := os.Stat(legacy); err == nil {
+		return paths, nil
+	}
+
+	if dir := xdgDir("XDG_CONFIG_HOME"); dir != "" {
+		paths.ConfigDir = dir
+	}
+	if dir := xdgDir("XDG_DATA_HOME"); dir != "" {
+		paths.DataDir = dir
+	}
+	if dir := xdgDir("XDG_CACHE_HOME"); dir != "" {
+		paths.CacheDir = dir
+	}
+	return paths, nil
+}
+
+// xdgDir devuelve <$env>/kolyn; la especificación XDG ignora las rutas relativas
+func xdgDir(env string) string {
+	dir := os.Getenv(env)
+	if dir == "" || !filepath.IsAbs(dir) {
+		return ""
+	}
+	return filepath.Join(dir, xdgDirName)
+}
+
+// ConfigFile es la configuración global (config.json)
+func (p Paths) ConfigFile() string {
+	return filepath.Join(p.ConfigDir, "config.json")
+}
+
+// SkillsDir guarda las skills propias del usuario (source "local")
+func (p Paths) SkillsDir() string {
+	return filepath.Join(p.DataDir, "skills")
+}
+
+// SourcesDir guarda un clon, enlace o extracción por cada source
+func (p Paths) SourcesDir() string {
+	return filepath.Join(p.DataDir, "sources")
+}
+
+// SourceDir es el directorio de un source dentro de SourcesDir (ver SourcePath)
+func (p Paths) SourceDir(name string) (string, error) {
+	return SourcePath(p.SourcesDir(), name)
+}
+
+// TemplatesDir guarda los templates de docker compose de kolyn up
+func (p Paths) TemplatesDir() string {
+	return filepath.Join(p.DataDir, "templates")
+}
+
+// ServicesDir guarda los servicios creados con kolyn up
+func (p Paths) ServicesDir() string {
+	return filepath.Join(p.DataDir, "services")
+}
+
+// DisplayPath acorta una ruta bajo el home del usuario a "~/..." para mostrarla en mensajes
+func DisplayPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	rel, err := filepath.Rel(home, path)
+	if err == nil && rel == "." {
+		return "~"
+	}
+	if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "~/" + filepath.ToSlash(rel)
+	}
+	return path
+}
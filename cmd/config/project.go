@@ -0,0 +1,210 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// ProjectConfigFile es el archivo de configuración del proyecto, junto a Agent.md
+const ProjectConfigFile = ".kolyn.json"
+
+// DefaultAgentTarget es el archivo de contexto que genera kolyn init si el proyecto no define targets
+const DefaultAgentTarget = "Agent.md"
+
+// ProjectConfig es la configuración de un proyecto (.kolyn.json); se aplica encima de la global
+type ProjectConfig struct {
+	ProjectName    string            `json:"project_name,omitempty"`
+	SkillsSources  []SkillSource     `json:"skills_sources,omitempty"`  // Se suman a las globales; el mismo name reemplaza al global
+	Targets        []string          `json:"targets,omitempty"`         // Archivos de contexto que genera init (ej. Agent.md, CLAUDE.md)
+	RequiredSkills []string          `json:"required_skills,omitempty"` // IDs que init siempre vendoriza y check exige
+	Check          CheckSettings     `json:"check,omitzero"`
+	Variables      map[string]string `json:"variables,omitempty"` // Valores para las plantillas de las skills
+
+	Root string `json:"-"` // Directorio donde está el .kolyn.json
+}
+
+// CheckSettings ajusta kolyn check para el proyecto
+type CheckSettings struct {
+	Skip    []string `json:"skip,omitempty"`     // IDs de skills que no se auditan
+	EnvFile string   `json:"env_file,omitempty"` // Archivo con las variables de entorno (por defecto .env)
+}
+
+// AgentTargets devuelve los archivos de contexto del proyecto; el primero es el que leen init y check
+func (p *ProjectConfig) AgentTargets() []string {
+	if p == nil || len(p.Targets) == 0 {
+		return []string{DefaultAgentTarget}
+	}
+	return p.Targets
+}
+
+// Validate revisa los sources del proyecto igual que los globales; además, las rutas
+// relativas de los sources locales no pueden salir del proyecto
+func (p *ProjectConfig) Validate() error {
+	names := make(map[string]bool)
+	for _, s := range p.SkillsSources {
+		if err := ValidateSourceName(s.Name); err != nil {
+			return err
+		}
+		if names[s.Name] {
+			return fmt.Errorf("%s: el source '%s' está repetido", ProjectConfigFile, s.Name)
+		}
+		names[s.Name] = true
+		if s.Mode != "" && s.Mode != SourceModeLink && s.Mode != SourceModeCopy {
+			return fmt.Errorf("%s: skills_sources.%s.mode inválido '%s' (usa %s o %s)", ProjectConfigFile, s.Name, s.Mode, SourceModeLink, SourceModeCopy)
+		}
+		if s.Kind() != SourceGit && !s.IsRemote() {
+			s.ProjectRoot = p.Root
+			if _, err := s.LocalPath(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, target := range p.Targets {
+		if _, err := TargetPath(p.Root, target); err != nil {
+			return fmt.Errorf("%s: %w", ProjectConfigFile, err)
+		}
+	}
+	return nil
+}
+
+// TargetPath devuelve la ruta de un archivo de contexto (target) dentro de root; los targets
+// son relativos al proyecto y no pueden salir de él (ej. "../../.bashrc")
+func TargetPath(root, target string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(target))
+	if target == "" || filepath.IsAbs(clean) || filepath.VolumeName(clean) != "" ||
+		clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("target inválido '%s' (usa una ruta relativa dentro del proyecto)", target)
+	}
+	path := filepath.Join(root, clean)
+	if !IsWithin(root, path) {
+		return "", fmt.Errorf("target inválido '%s' (usa una ruta relativa dentro del proyecto)", target)
+	}
+	return path, nil
+}
+
+// FindProjectRoot sube desde dir hasta encontrar un .kolyn.json; devuelve "" si no hay ninguno
+func FindProjectRoot(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if info, err := os.Stat(GetProjectConfigPath(dir)); err == nil && !info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// FindProjectConfig carga el .kolyn.json más cercano a dir y el directorio donde está.
+// Sin proyecto devuelve nil, "".
+func FindProjectConfig(dir string) (*ProjectConfig, string, error) {
+	root := FindProjectRoot(dir)
+	if root == "" {
+		return nil, "", nil
+	}
+	cfg, err := LoadProjectConfig(root)
+	if err != nil {
+		return nil, root, err
+	}
+	return cfg, root, nil
+}
+
+// WithProject devuelve la configuración efectiva: la global con los sources del proyecto
+// encima. Un source del proyecto con el mismo name que uno global lo reemplaza.
+func (c *GlobalConfig) WithProject(p *ProjectConfig) *GlobalConfig {
+	merged := GlobalConfig{}
+	if c != nil {
+		merged = *c
+	}
+	if p == nil || len(p.SkillsSources) == 0 {
+		return &merged
+	}
+
+	sources := make([]SkillSource, 0, len(merged.SkillsSources)+len(p.SkillsSources))
+	overridden := make(map[string]bool)
+	for _, s := range p.SkillsSources {
+		overridden[s.Name] = true
+	}
+	for _, s := range merged.SkillsSources {
+		if !overridden[s.Name] {
+			sources = append(sources, s)
+		}
+	}
+	for _, s := range p.SkillsSources {
+		s.ProjectRoot = p.Root
+		sources = append(sources, s)
+	}
+	merged.SkillsSources = sources
+	return &merged
+}
+
+func GetProjectConfigPath(root string) string {
+	return filepath.Join(root, ProjectConfigFile)
+}
+
+func LoadProjectConfig(root string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(GetProjectConfigPath(root))
+	if os.IsNotExist(err) {
+		return nil, nil // Not found
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := ProjectConfig{Root: root}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", ProjectConfigFile, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SaveProjectConfig escribe los campos de cfg en .kolyn.json. Las claves que ProjectConfig no
+// modela (ej. las de otras herramientas) se conservan tal cual.
+func SaveProjectConfig(root string, cfg *ProjectConfig) error {
+	path := GetProjectConfigPath(root)
+	file := make(map[string]json.RawMessage)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("error parsing %s: %w", ProjectConfigFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	var managed map[string]json.RawMessage
+	if err := json.Unmarshal(data, &managed); err != nil {
+		return err
+	}
+	// Los campos vacíos (omitempty) se quitan del archivo
+	typ := reflect.TypeOf(ProjectConfig{})
+	for i := 0; i < typ.NumField(); i++ {
+		if name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			delete(file, name)
+		}
+	}
+	for key, value := range managed {
+		file[key] = value
+	}
+
+	data, err = json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
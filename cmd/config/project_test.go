@@ -0,0 +1,148 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTargetPath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "proyecto")
+
+	tests := []struct {
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{target: "Agent.md", want: filepath.Join(root, "Agent.md")},
+		{target: "docs/CLAUDE.md", want: filepath.Join(root, "docs", "CLAUDE.md")},
+		{target: "./.cursor/rules.md", want: filepath.Join(root, ".cursor", "rules.md")},
+		{target: "docs/../Agent.md", want: filepath.Join(root, "Agent.md")},
+		{target: "..hidden.md", want: filepath.Join(root, "..hidden.md")},
+		{target: "", wantErr: true},
+		{target: ".", wantErr: true},
+		{target: "..", wantErr: true},
+		{target: "../../.bashrc", wantErr: true},
+		{target: "docs/../../outside.md", wantErr: true},
+		{target: "/etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			got, err := TargetPath(root, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadProjectConfigRejectsEscapingTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{name: "targets válidos", config: `{"targets": ["Agent.md", "docs/CLAUDE.md"]}`},
+		{name: "target fuera del proyecto", config: `{"targets": ["../../.bashrc"]}`, wantErr: true},
+		{name: "target absoluto", config: `{"targets": ["/tmp/Agent.md"]}`, wantErr: true},
+		{name: "source local fuera del proyecto", config: `{"skills_sources": [{"name": "x", "url": "../outside"}]}`, wantErr: true},
+		{name: "source local dentro del proyecto", config: `{"skills_sources": [{"name": "x", "url": "./docs/skills"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(GetProjectConfigPath(root), []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadProjectConfig(root)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSaveProjectConfigKeepsUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		update   func(c *ProjectConfig)
+		want     map[string]string
+		gone     []string
+	}{
+		{
+			name:     "variables nuevas",
+			existing: `{"project_name": "Billing", "$schema": "https://example.com/kolyn.json", "otra_herramienta": {"x": 1}}`,
+			update:   func(c *ProjectConfig) { c.Variables = map[string]string{"db": "postgres"} },
+			want: map[string]string{
+				"project_name":     `"Billing"`,
+				"$schema":          `"https://example.com/kolyn.json"`,
+				"otra_herramienta": `{"x":1}`,
+				"variables":        `{"db":"postgres"}`,
+			},
+		},
+		{
+			name:     "pin de un source",
+			existing: `{"skills_sources": [{"name": "team", "url": "git@example.com:team.git", "ref": "v1.0.0"}], "notas": "no tocar"}`,
+			update:   func(c *ProjectConfig) { c.SkillsSources[0].Ref = "v1.2.0" },
+			want: map[string]string{
+				"skills_sources": `[{"name":"team","url":"git@example.com:team.git","ref":"v1.2.0"}]`,
+				"notas":          `"no tocar"`,
+			},
+		},
+		{
+			name:     "campo vaciado se quita",
+			existing: `{"targets": ["CLAUDE.md"], "extra": true}`,
+			update:   func(c *ProjectConfig) { c.Targets = nil },
+			want:     map[string]string{"extra": `true`},
+			gone:     []string{"targets"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(GetProjectConfigPath(root), []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := LoadProjectConfig(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.update(cfg)
+			if err := SaveProjectConfig(root, cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := os.ReadFile(GetProjectConfigPath(root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]json.RawMessage
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range tt.want {
+				var buf bytes.Buffer
+				if err := json.Compact(&buf, got[key]); err != nil {
+					t.Fatalf("%s: %v", key, err)
+				}
+				if buf.String() != want {
+					t.Errorf("%s = %s, want %s", key, buf.String(), want)
+				}
+			}
+			for _, key := range tt.gone {
+				if _, ok := got[key]; ok {
+					t.Errorf("%s sigue en el archivo", key)
+				}
+			}
+		})
+	}
+}
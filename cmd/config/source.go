@@ -0,0 +1,203 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SkillSource es un repositorio de skills. En config.json se acepta la forma antigua como
+// string ("url" o "url#ref") y se guarda siempre como objeto.
+type SkillSource struct {
+	Name     string `json:"name"`               // Carpeta en ~/.kolyn/sources y prefijo de los IDs de skills
+	URL      string `json:"url"`                // URL git, directorio local o archivo .tar.gz/.zip
+	Ref      string `json:"ref,omitempty"`      // Rama, tag o commit fijado
+	Subdir   string `json:"subdir,omitempty"`   // Carpeta del repo donde están las skills
+	Priority int    `json:"priority,omitempty"` // Mayor prioridad gana cuando dos sources tienen el mismo ID
+	Enabled  *bool  `json:"enabled,omitempty"`  // nil = habilitado
+	Auth     string `json:"auth,omitempty"`     // Pista que se muestra si falla el acceso (ej. "pide acceso en #infra")
+	Mode     string `json:"mode,omitempty"`     // Sources locales: "link" (defecto) o "copy"
+
+	Sparse    []string `json:"sparse,omitempty"`     // Repos git: carpetas a descargar (sparse checkout)
+	FullClone bool     `json:"full_clone,omitempty"` // Repos git: historial completo en vez de --depth 1
+
+	ProjectRoot string `json:"-"` // Si viene de un .kolyn.json: el directorio del proyecto (base de las rutas relativas)
+}
+
+// Tipos de source según su URL
+const (
+	SourceGit     = "git"     // Repo git (ssh, https, ...)
+	SourceDir     = "dir"     // Directorio local (/ruta, ~/ruta, ./ruta o file:///ruta)
+	SourceArchive = "archive" // .tar.gz, .tgz, .tar o .zip local (file://) o remoto (http/https)
+)
+
+// Modos de un source local
+const (
+	SourceModeLink = "link"
+	SourceModeCopy = "copy"
+)
+
+// Kind devuelve el tipo de source según su URL
+func (s SkillSource) Kind() string {
+	lower := strings.ToLower(s.URL)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+		if strings.HasSuffix(lower, ext) &&
+			(strings.HasPrefix(lower, "file://") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) {
+			return SourceArchive
+		}
+	}
+	if strings.HasPrefix(lower, "file://") || filepath.IsAbs(s.URL) ||
+		strings.HasPrefix(s.URL, "~/") || strings.HasPrefix(s.URL, "./") || strings.HasPrefix(s.URL, "../") {
+		return SourceDir
+	}
+	return SourceGit
+}
+
+// IsRemote indica si sincronizar el source requiere red (repos git y archivos http/https)
+func (s SkillSource) IsRemote() bool {
+	switch s.Kind() {
+	case SourceGit:
+		return true
+	case SourceArchive:
+		return !strings.HasPrefix(strings.ToLower(s.URL), "file://")
+	}
+	return false
+}
+
+// LocalPath devuelve la ruta en disco de un source local (directorio o archivo file://)
+func (s SkillSource) LocalPath() (string, error) {
+	p := s.URL
+	if strings.HasPrefix(strings.ToLower(p), "file://") {
+		u, err := url.Parse(p)
+		if err != nil {
+			return "", fmt.Errorf("URL inválida %s: %w", p, err)
+		}
+		p = u.Path
+	}
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		p = filepath.Join(home, rest)
+	}
+	if s.ProjectRoot != "" && !filepath.IsAbs(filepath.FromSlash(p)) {
+		// Las rutas relativas de un .kolyn.json no pueden salir del proyecto
+		p = filepath.Join(s.ProjectRoot, filepath.FromSlash(p))
+		if !IsWithin(s.ProjectRoot, p) {
+			return "", fmt.Errorf("el source '%s' apunta fuera del proyecto: %s", s.Name, s.URL)
+		}
+	}
+	return filepath.Abs(filepath.FromSlash(p))
+}
+
+// ParseSkillSource interpreta la forma corta "url#ref"
+func ParseSkillSource(s string) SkillSource {
+	repoURL, ref, _ := strings.Cut(strings.TrimSpace(s), "#")
+	return SkillSource{Name: SanitizeRepoName(repoURL), URL: repoURL, Ref: ref}
+}
+
+// String devuelve la forma corta "url#ref"
+func (s SkillSource) String() string {
+	if s.Ref == "" {
+		return s.URL
+	}
+	return s.URL + "#" + s.Ref
+}
+
+// IsEnabled indica si el source se sincroniza y se usa al buscar skills
+func (s SkillSource) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+func (s *SkillSource) UnmarshalJSON(data []byte) error {
+	var short string
+	if err := json.Unmarshal(data, &short); err == nil {
+		*s = ParseSkillSource(short)
+		return nil
+	}
+
+	type plain SkillSource // Evita recursión en UnmarshalJSON
+	var obj plain
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("skills_sources: se esperaba \"url#ref\" u objeto {name, url, ref, ...}: %w", err)
+	}
+	if obj.URL == "" {
+		return fmt.Errorf("skills_sources: falta url")
+	}
+	if obj.Name == "" {
+		// El nombre por defecto es el de siempre para que los IDs de skills no cambien
+		obj.Name = SanitizeRepoName(obj.URL)
+	}
+	*s = SkillSource(obj)
+	return nil
+}
+
+// ValidateSourceName revisa que name sirva como carpeta dentro de sources/: no puede estar
+// vacío, ser "." o "..", ser absoluto ni contener separadores de ruta
+func ValidateSourceName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("skills_sources: falta name")
+	case name == "." || name == "..":
+		return fmt.Errorf("skills_sources: name inválido '%s'", name)
+	case filepath.IsAbs(name) || strings.ContainsAny(name, `/\`) || filepath.VolumeName(name) != "":
+		return fmt.Errorf("skills_sources: name inválido '%s' (no puede ser una ruta)", name)
+	}
+	return nil
+}
+
+// SourcePath devuelve el directorio de un source dentro de baseDir (sources/) y garantiza
+// que no se salga de él, antes de escribir o borrar ahí
+func SourcePath(baseDir, name string) (string, error) {
+	if err := ValidateSourceName(name); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(baseDir, name)
+	if filepath.Clean(dir) == filepath.Clean(baseDir) || !IsWithin(baseDir, dir) {
+		return "", fmt.Errorf("el source '%s' queda fuera de %s", name, baseDir)
+	}
+	return dir, nil
+}
+
+// IsWithin indica si path es baseDir o está dentro de él
+func IsWithin(baseDir, path string) bool {
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil || filepath.IsAbs(rel) {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// ActiveSources devuelve los sources habilitados ordenados por prioridad (mayor primero);
+// a igual prioridad se respeta el orden de config.json
+func (c *GlobalConfig) ActiveSources() []SkillSource {
+	var active []SkillSource
+	for _, s := range c.SkillsSources {
+		if s.IsEnabled() {
+			active = append(active, s)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Priority > active[j].Priority
+	})
+	return active
+}
+
+// SanitizeRepoName convierte una URL git en el nombre de carpeta del source
+// (ej. git@github.com:org/skills.git → github.com-org-skills)
+func SanitizeRepoName(repoURL string) string {
+	name := repoURL
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.TrimPrefix(name, "http://")
+	name = strings.TrimPrefix(name, "git@")
+	name = strings.TrimSuffix(name, ".git")
+	name = strings.ReplaceAll(name, ":", "/")
+	name = strings.ReplaceAll(name, "/", "-")
+	name = strings.ReplaceAll(name, `\`, "-")
+	return name
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSkillSourceUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    SkillSource
+		wantErr bool
+	}{
+		{
+			name:  "string legacy",
+			input: `"git@github.com:org/skills.git"`,
+			want:  SkillSource{Name: "github.com-org-skills", URL: "git@github.com:org/skills.git"},
+		},
+		{
+			name:  "string legacy con ref",
+			input: `"https://github.com/org/skills.git#v1.4.0"`,
+			want:  SkillSource{Name: "github.com-org-skills", URL: "https://github.com/org/skills.git", Ref: "v1.4.0"},
+		},
+		{
+			name:  "objeto sin name",
+			input: `{"url": "git@github.com:org/skills.git", "priority": 10}`,
+			want:  SkillSource{Name: "github.com-org-skills", URL: "git@github.com:org/skills.git", Priority: 10},
+		},
+		{
+			name:  "objeto con name",
+			input: `{"name": "personal", "url": "~/dev/skills", "mode": "copy"}`,
+			want:  SkillSource{Name: "personal", URL: "~/dev/skills", Mode: SourceModeCopy},
+		},
+		{
+			name:    "objeto sin url",
+			input:   `{"name": "personal"}`,
+			wantErr: true,
+		},
+		{
+			name:    "tipo inválido",
+			input:   `42`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SkillSource
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalConfigLegacySourcesMigrate(t *testing.T) {
+	var cfg GlobalConfig
+	input := `{"skills_sources": ["git@github.com:org/skills.git#v1.0.0", {"name": "personal", "url": "/tmp/skills"}]}`
+	if err := json.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(cfg.SkillsSources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"name":"github.com-org-skills","url":"git@github.com:org/skills.git","ref":"v1.0.0"},{"name":"personal","url":"/tmp/skills"}]`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestValidateSourceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"github.com-org-skills", false},
+		{"personal", false},
+		{"..skills", false},
+		{"", true},
+		{".", true},
+		{"..", true},
+		{"../victim", true},
+		{"a/b", true},
+		{`a\b`, true},
+		{"/tmp/evil", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSourceName(tt.name); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSourceName(%q) = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSourcePath(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "sources")
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "personal", want: filepath.Join(base, "personal")},
+		{name: "../victim", wantErr: true},
+		{name: "../../tmp/evil", wantErr: true},
+		{name: "..", wantErr: true},
+		{name: ".", wantErr: true},
+		{name: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SourcePath(base, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalConfigValidateRejectsPathNames(t *testing.T) {
+	var cfg GlobalConfig
+	input := `{"skills_sources": [{"name": "../victim", "url": "file:///tmp/arc/a.tar.gz"}]}`
+	if err := json.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate aceptó un name con '..'")
+	}
+}
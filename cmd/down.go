@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
 )
@@ -39,11 +40,11 @@ type ServiceInfo struct {
 }
 
 func runStatusCommand(ctx context.Context) error {
-	homeDir, err := os.UserHomeDir()
+	paths, err := config.GetPaths()
 	if err != nil {
-		return fmt.Errorf("error obteniendo home dir: %w", err)
+		return err
 	}
-	dockerDir := filepath.Join(homeDir, ".kolyn", "services")
+	dockerDir := paths.ServicesDir()
 
 	services, err := getExistingServices(dockerDir)
 	if err != nil {
@@ -53,7 +54,7 @@ func runStatusCommand(ctx context.Context) error {
 	ui.ShowSection("📋 Servicios Docker")
 
 	if len(services) == 0 {
-		ui.Gray.Println("  No hay servicios configurados en ~/.kolyn/services/")
+		ui.Gray.Printf("  No hay servicios configurados en %s\n", config.DisplayPath(dockerDir))
 		ui.Gray.Println("  Ejecuta 'kolyn up' para crear uno.")
 		return nil
 	}
@@ -83,11 +84,11 @@ func runStatusCommand(ctx context.Context) error {
 }
 
 func runDockerDownCommand(ctx context.Context) error {
-	homeDir, err := os.UserHomeDir()
+	paths, err := config.GetPaths()
 	if err != nil {
-		return fmt.Errorf("error obteniendo home dir: %w", err)
+		return err
 	}
-	dockerDir := filepath.Join(homeDir, ".kolyn", "services")
+	dockerDir := paths.ServicesDir()
 
 	services, err := getExistingServices(dockerDir)
 	if err != nil {
@@ -95,13 +96,13 @@ func runDockerDownCommand(ctx context.Context) error {
 	}
 
 	if len(services) == 0 {
-		ui.PrintInfo("No hay servicios levantados en ~/.kolyn/services/")
+		ui.PrintInfo("No hay servicios levantados en %s", config.DisplayPath(dockerDir))
 		return nil
 	}
 
 	ui.ShowSection("🛑 Kolyn Down - Detener Servicios")
 
-	fmt.Println("Servicios disponibles:\n")
+	fmt.Print("Servicios disponibles:\n\n")
 
 	for i, s := range services {
 		status := getServiceStatus(ctx, s.Path)
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// removePrefix marca en una skill hija una regla heredada que se debe quitar (ej. "!usa pnpm")
+const removePrefix = "!"
+
+// skillResolver resuelve la herencia `extends` de skills a partir de un mapa ID → ruta del archivo
+type skillResolver struct {
+	paths map[string]string
+	cache map[string]*SkillFrontmatter
+}
+
+func newSkillResolver(paths map[string]string) *skillResolver {
+	return &skillResolver{paths: paths, cache: make(map[string]*SkillFrontmatter)}
+}
+
+func (r *skillResolver) load(id string) (*SkillFrontmatter, error) {
+	if fm, ok := r.cache[id]; ok {
+		return fm, nil
+	}
+	path, ok := r.paths[id]
+	if !ok {
+		return nil, fmt.Errorf("no se encontró la skill padre '%s'", id)
+	}
+	fm, err := parseSkillFrontmatter(path)
+	if err != nil {
+		return nil, fmt.Errorf("skill padre '%s': %w", id, err)
+	}
+	r.cache[id] = fm
+	return fm, nil
+}
+
+// resolve devuelve el frontmatter con agent_rules y check heredados de sus padres.
+// Los padres se aplican en el orden de `extends` y la hija al final: las listas se unen sin
+// duplicados, una entrada "!valor" quita un valor heredado y fail_message de la hija reemplaza al del padre.
+// Una skill sin `extends` se devuelve tal cual: sin padre del que quitar, "!valor" es un valor literal.
+func (r *skillResolver) resolve(id string, fm *SkillFrontmatter) (*SkillFrontmatter, error) {
+	return r.resolveChain(fm, []string{id})
+}
+
+func (r *skillResolver) resolveChain(fm *SkillFrontmatter, chain []string) (*SkillFrontmatter, error) {
+	if len(fm.Extends) == 0 {
+		resolved := *fm
+		return &resolved, nil
+	}
+
+	var inherited SkillFrontmatter
+	for _, parentID := range fm.Extends {
+		for _, seen := range chain {
+			if seen == parentID {
+				return nil, fmt.Errorf("ciclo en extends: %s -> %s", strings.Join(chain, " -> "), parentID)
+			}
+		}
+
+		parent, err := r.load(parentID)
+		if err != nil {
+			return nil, err
+		}
+		resolvedParent, err := r.resolveChain(parent, append(chain[:len(chain):len(chain)], parentID))
+		if err != nil {
+			return nil, err
+		}
+		// Las reglas ya resueltas del padre son valores: sus "!" literales no quitan nada
+		inherited.AgentRules = unionStrings(inherited.AgentRules, resolvedParent.AgentRules)
+		inherited.Check = mergeChecks(inherited.Check, resolvedParent.Check, unionStrings)
+	}
+
+	resolved := *fm
+	resolved.AgentRules = mergeInherited(inherited.AgentRules, fm.AgentRules)
+	resolved.Check = mergeChecks(inherited.Check, fm.Check, mergeInherited)
+	return &resolved, nil
+}
+
+// mergeInherited agrega los valores de la hija a los heredados, sin duplicados, aplicando las
+// entradas "!valor". Solo se usa cuando la hija tiene padres (extends).
+func mergeInherited(base, child []string) []string {
+	result := append([]string(nil), base...)
+	for _, item := range child {
+		if removed, ok := strings.CutPrefix(item, removePrefix); ok {
+			result = removeString(result, removed)
+			continue
+		}
+		if !containsString(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// unionStrings agrega items a base sin duplicados, sin interpretar "!"
+func unionStrings(base, items []string) []string {
+	result := append([]string(nil), base...)
+	for _, item := range items {
+		if !containsString(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// mergeChecks combina las reglas de check lista por lista con merge
+func mergeChecks(base, child SkillCheck, merge func(base, child []string) []string) SkillCheck {
+	merged := SkillCheck{
+		RequiredDeps:  merge(base.RequiredDeps, child.RequiredDeps),
+		DepsExistAny:  merge(base.DepsExistAny, child.DepsExistAny),
+		ForbiddenDeps: merge(base.ForbiddenDeps, child.ForbiddenDeps),
+		FilesExist:    merge(base.FilesExist, child.FilesExist),
+		FilesExistAny: merge(base.FilesExistAny, child.FilesExistAny),
+		EnvVars:       merge(base.EnvVars, child.EnvVars),
+		FailMessage:   base.FailMessage,
+	}
+	if child.FailMessage != "" {
+		merged.FailMessage = child.FailMessage
+	}
+	return merged
+}
+
+// withoutSeen quita las reglas que otra skill activa ya evaluó (ej. el padre también está activo)
+// y registra las restantes en seen
+func (c SkillCheck) withoutSeen(seen map[string]bool) SkillCheck {
+	filter := func(kind string, items []string) []string {
+		var result []string
+		for _, item := range items {
+			if key := kind + ":" + item; !seen[key] {
+				seen[key] = true
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+	filterGroup := func(kind string, items []string) []string {
+		if key := kind + ":" + strings.Join(items, ","); len(items) > 0 && !seen[key] {
+			seen[key] = true
+			return items
+		}
+		return nil
+	}
+
+	return SkillCheck{
+		RequiredDeps:  filter("dep", c.RequiredDeps),
+		DepsExistAny:  filterGroup("deps_any", c.DepsExistAny),
+		ForbiddenDeps: filter("forbidden", c.ForbiddenDeps),
+		FilesExist:    filter("file", c.FilesExist),
+		FilesExistAny: filterGroup("files_any", c.FilesExistAny),
+		EnvVars:       filter("env", c.EnvVars),
+		FailMessage:   c.FailMessage,
+	}
+}
+
+// expandSkillParents agrega a la selección los padres (transitivos) de cada skill para que el
+// proyecto quede autónomo. Devuelve los IDs de padres que no existen en ningún source.
+func expandSkillParents(selected, available []SkillInfo) ([]SkillInfo, []string) {
+	byID := make(map[string]SkillInfo, len(available))
+	for _, s := range available {
+		if _, dup := byID[s.ID]; !dup {
+			byID[s.ID] = s
+		}
+	}
+
+	result := append([]SkillInfo(nil), selected...)
+	included := make(map[string]bool)
+	for _, s := range selected {
+		included[s.ID] = true
+	}
+
+	var missing []string
+	for i := 0; i < len(result); i++ {
+		for _, parentID := range result[i].Extends {
+			if included[parentID] {
+				continue
+			}
+			included[parentID] = true
+
+			parent, ok := byID[parentID]
+			if !ok {
+				missing = append(missing, parentID)
+				continue
+			}
+			result = append(result, parent)
+		}
+	}
+	return result, missing
+}
+
+func removeString(list []string, value string) []string {
+	result := list[:0]
+	for _, item := range list {
+		if item != value {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeInherited(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  []string
+		child []string
+		want  []string
+	}{
+		{
+			name:  "une sin duplicados",
+			base:  []string{"a", "b"},
+			child: []string{"b", "c"},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "quita un valor heredado",
+			base:  []string{"usa pnpm", "usa eslint"},
+			child: []string{"!usa pnpm", "usa bun"},
+			want:  []string{"usa eslint", "usa bun"},
+		},
+		{
+			name:  "quitar un valor que no existe no hace nada",
+			base:  []string{"a"},
+			child: []string{"!z"},
+			want:  []string{"a"},
+		},
+		{
+			name:  "no modifica base",
+			base:  []string{"a", "b"},
+			child: []string{"!a"},
+			want:  []string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := append([]string(nil), tt.base...)
+			got := mergeInherited(base, tt.child)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(base, tt.base) {
+				t.Errorf("base modificado: %q", base)
+			}
+		})
+	}
+}
+
+func TestSkillResolverResolve(t *testing.T) {
+	skills := map[string]*SkillFrontmatter{
+		"org/base": {
+			AgentRules: []string{"usa pnpm", "usa eslint", "!important es literal"},
+			Check:      SkillCheck{RequiredDeps: []string{"react"}, EnvVars: []string{"API_URL"}, FailMessage: "base"},
+		},
+		"org/style": {
+			AgentRules: []string{"usa tailwind", "usa eslint"},
+		},
+		"org/next": {
+			Extends:    []string{"org/base", "org/style"},
+			AgentRules: []string{"!usa pnpm", "usa bun"},
+			Check:      SkillCheck{RequiredDeps: []string{"next"}, EnvVars: []string{"!API_URL"}, FailMessage: "next"},
+		},
+		"org/cycle-a": {Extends: []string{"org/cycle-b"}},
+		"org/cycle-b": {Extends: []string{"org/cycle-a"}},
+		"org/self":    {Extends: []string{"org/self"}},
+		"org/orphan":  {Extends: []string{"org/missing"}},
+	}
+	newResolver := func() *skillResolver {
+		r := newSkillResolver(nil)
+		for id, fm := range skills {
+			r.cache[id] = fm
+		}
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		wantRules []string
+		wantCheck SkillCheck
+		wantErr   string
+	}{
+		{
+			name:      "sin extends las reglas con ! son literales",
+			id:        "org/base",
+			wantRules: []string{"usa pnpm", "usa eslint", "!important es literal"},
+			wantCheck: SkillCheck{RequiredDeps: []string{"react"}, EnvVars: []string{"API_URL"}, FailMessage: "base"},
+		},
+		{
+			name:      "hereda de varios padres y quita valores",
+			id:        "org/next",
+			wantRules: []string{"usa eslint", "!important es literal", "usa tailwind", "usa bun"},
+			wantCheck: SkillCheck{RequiredDeps: []string{"react", "next"}, EnvVars: []string{}, FailMessage: "next"},
+		},
+		{
+			name:    "ciclo entre dos skills",
+			id:      "org/cycle-a",
+			wantErr: "ciclo en extends: org/cycle-a -> org/cycle-b -> org/cycle-a",
+		},
+		{
+			name:    "se extiende a sí misma",
+			id:      "org/self",
+			wantErr: "ciclo en extends",
+		},
+		{
+			name:    "padre inexistente",
+			id:      "org/orphan",
+			wantErr: "no se encontró la skill padre 'org/missing'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newResolver().resolve(tt.id, skills[tt.id])
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.AgentRules, tt.wantRules) {
+				t.Errorf("agent_rules = %q, want %q", got.AgentRules, tt.wantRules)
+			}
+			if !reflect.DeepEqual(got.Check, tt.wantCheck) {
+				t.Errorf("check = %+v, want %+v", got.Check, tt.wantCheck)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var skillsSchemaOutput string
+
+var skillsSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Exporta el JSON Schema del frontmatter de las skills",
+	Long: `Genera el JSON Schema del frontmatter a partir del modelo que usa Kolyn,
+para que los editores validen y autocompleten los archivos de skills.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSkillsSchema(skillsSchemaOutput)
+	},
+}
+
+func init() {
+	skillsSchemaCmd.Flags().StringVarP(&skillsSchemaOutput, "output", "o", "", "Escribe el schema en un archivo en lugar de stdout")
+	skillsCmd.AddCommand(skillsSchemaCmd)
+}
+
+// SkillFrontmatter es el modelo único del frontmatter YAML de una skill.
+// init, check, scaffold, el linter y el JSON Schema se derivan de esta estructura:
+// los tags `desc` documentan cada campo y `enum` referencia a schemaEnums.
+type SkillFrontmatter struct {
+	ID          string     `yaml:"id" desc:"Identificador canónico de la skill. Por defecto source/category/name."`
+	Name        string     `yaml:"name" desc:"Nombre visible de la skill."`
+	Description string     `yaml:"description" desc:"Descripción corta que se muestra en el selector de kolyn init."`
+	AgentRules  []string   `yaml:"agent_rules" desc:"Reglas críticas que se inyectan en Agent.md."`
+	AppliesTo   []string   `yaml:"applies_to" enum:"project_types" desc:"Tipos de proyecto a los que aplica la skill."`
+	Capability  string     `yaml:"capability" examples:"capabilities" desc:"Capability que cubre la skill (core, ui, database, ...)."`
+	Extends     []string   `yaml:"extends" desc:"IDs de skills padre de las que hereda agent_rules y check. \"!valor\" quita un valor heredado."`
+	Check       SkillCheck `yaml:"check" desc:"Reglas que audita kolyn check."`
+
+	// Idiomas: variantes de la skill por idioma (además de la convención nombre.<idioma>.md)
+	Translations map[string]string `yaml:"translations" enum:"languages" desc:"Archivo de la skill en cada idioma (es, en), relativo a esta skill."`
+
+	// Plantillas: si la skill declara variables, su contenido se renderiza con text/template al vendorizarla
+	Variables []SkillVariable `yaml:"variables" desc:"Variables de plantilla ({{ .nombre }}) que usa la skill. project_name, project_type, package_manager y src_dir se detectan solas y se pueden usar sin declararlas."`
+
+	// Skills de scaffold
+	Type          string            `yaml:"type" desc:"Tipo de proyecto que genera el scaffold (ej. web)."`
+	Framework     string            `yaml:"framework" desc:"Framework del scaffold (ej. nextjs)."`
+	CreateCommand string            `yaml:"create_command" desc:"Comando para crear el proyecto base. {name} se reemplaza por el nombre."`
+	Structure     ScaffoldStructure `yaml:"structure" desc:"Directorios y archivos que el scaffold crea o audita."`
+}
+
+// SkillCheck define las reglas que audita kolyn check
+type SkillCheck struct {
+	RequiredDeps  []string `yaml:"required_deps" desc:"Dependencias de package.json obligatorias. /patrón/ se evalúa como regex."`
+	DepsExistAny  []string `yaml:"deps_exist_any" desc:"Se requiere al menos una de estas dependencias."`
+	ForbiddenDeps []string `yaml:"forbidden_deps" desc:"Dependencias prohibidas."`
+	FilesExist    []string `yaml:"files_exist" desc:"Archivos obligatorios, relativos a la raíz del proyecto."`
+	FilesExistAny []string `yaml:"files_exist_any" desc:"Se requiere al menos uno de estos archivos."`
+	EnvVars       []string `yaml:"env_vars" desc:"Variables que deben estar definidas en .env."`
+	FailMessage   string   `yaml:"fail_message" desc:"Sugerencia que se muestra cuando falla alguna regla."`
+}
+
+// isEmpty indica si la skill no define reglas de check
+func (c SkillCheck) isEmpty() bool {
+	return len(c.RequiredDeps) == 0 && len(c.ForbiddenDeps) == 0 && len(c.FilesExist) == 0 &&
+		len(c.DepsExistAny) == 0 && len(c.FilesExistAny) == 0 && len(c.EnvVars) == 0
+}
+
+// SkillVariable declara una variable de plantilla y cómo pedir su valor
+type SkillVariable struct {
+	Name    string `yaml:"name" desc:"Nombre de la variable, usado como {{ .nombre }}."`
+	Prompt  string `yaml:"prompt" desc:"Pregunta que hace kolyn init si el valor no está en .kolyn.json."`
+	Default string `yaml:"default" desc:"Valor por defecto."`
+}
+
+type ScaffoldFile struct {
+	Path    string `yaml:"path" desc:"Ruta del archivo relativa al proyecto."`
+	Content string `yaml:"content" desc:"Contenido inicial del archivo."`
+}
+
+type ScaffoldStructure struct {
+	Directories []string       `yaml:"directories" desc:"Directorios que deben existir."`
+	Files       []ScaffoldFile `yaml:"files" desc:"Archivos que deben existir."`
+}
+
+// knownProjectTypes son los valores válidos de applies_to (ver detectProjectType)
+var knownProjectTypes = []string{"nextjs", "go", "python", "node", "generic"}
+
+// knownLanguages son los idiomas de la CLI (ui.CurrentLanguage) y de las variantes de skills
+var knownLanguages = []string{"es", "en"}
+
+// knownCapabilities son las capabilities documentadas en el README
+var knownCapabilities = []string{"core", "ui", "database", "auth", "api", "devops"}
+
+// schemaEnums resuelve los tags `enum` y `examples` del modelo
+var schemaEnums = map[string][]string{
+	"project_types": knownProjectTypes,
+	"capabilities":  knownCapabilities,
+	"languages":     knownLanguages,
+}
+
+var errNoFrontmatter = errors.New("no frontmatter")
+
+// splitFrontmatter separa el YAML del cuerpo Markdown de una skill
+func splitFrontmatter(content []byte) (yamlContent, body []byte, err error) {
+	if !bytes.HasPrefix(content, []byte("---")) {
+		return nil, content, errNoFrontmatter
+	}
+	parts := bytes.SplitN(content, []byte("---"), 3)
+	if len(parts) < 3 {
+		return nil, content, fmt.Errorf("frontmatter mal formado")
+	}
+	return parts[1], parts[2], nil
+}
+
+// parseFrontmatter decodifica el frontmatter de una skill
+func parseFrontmatter(content []byte) (*SkillFrontmatter, error) {
+	yamlContent, _, err := splitFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var fm SkillFrontmatter
+	if err := yaml.Unmarshal(yamlContent, &fm); err != nil {
+		return nil, err
+	}
+	return &fm, nil
+}
+
+func parseSkillFrontmatter(path string) (*SkillFrontmatter, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseFrontmatter(content)
+}
+
+// yamlFieldName devuelve el nombre YAML de un campo o "" si se omite
+func yamlFieldName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+// runSkillsSchema imprime o guarda el JSON Schema del frontmatter
+func runSkillsSchema(output string) error {
+	data, err := json.MarshalIndent(frontmatterJSONSchema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error generando JSON: %w", err)
+	}
+
+	if output == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(output, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("error escribiendo schema: %w", err)
+	}
+	ui.PrintSuccess("Schema guardado en %s", output)
+	return nil
+}
+
+// frontmatterJSONSchema genera el JSON Schema del frontmatter a partir de SkillFrontmatter
+func frontmatterJSONSchema() map[string]interface{} {
+	schema := jsonSchemaFor(reflect.TypeOf(SkillFrontmatter{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = "https://github.com/isai-arellano/kolyn-cli/skill-frontmatter.schema.json"
+	schema["title"] = "Kolyn Skill Frontmatter"
+	schema["description"] = "Frontmatter YAML de una skill de Kolyn (" + Version + ")."
+	return schema
+}
+
+func jsonSchemaFor(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := yamlFieldName(f)
+			if name == "" {
+				continue
+			}
+
+			prop := jsonSchemaFor(f.Type)
+			if f.Type.Kind() == reflect.Struct {
+				prop["type"] = []string{"object", "null"} // Una clave vacía equivale al valor por defecto
+			}
+			if desc := f.Tag.Get("desc"); desc != "" {
+				prop["description"] = desc
+			}
+			if enum, ok := schemaEnums[f.Tag.Get("enum")]; ok {
+				target := prop
+				if items, isArray := prop["items"].(map[string]interface{}); isArray {
+					target = items
+				}
+				if f.Type.Kind() == reflect.Map {
+					target["propertyNames"] = map[string]interface{}{"enum": enum}
+				} else {
+					target["enum"] = enum
+				}
+			}
+			if examples, ok := schemaEnums[f.Tag.Get("examples")]; ok {
+				prop["examples"] = examples
+			}
+			properties[name] = prop
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  []string{"array", "null"},
+			"items": jsonSchemaFor(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 []string{"object", "null"},
+			"additionalProperties": jsonSchemaFor(t.Elem()),
+		}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+)
+
+const (
+	skillIndexFile    = "skills-index.json"
+	skillIndexVersion = 1
+)
+
+// SkillIndex es la caché persistente de skills escaneadas (~/.kolyn/cache/skills-index.json).
+// Cada entrada se reutiliza mientras el mtime y el tamaño del archivo no cambien.
+type SkillIndex struct {
+	Version      int                        `json:"version"`
+	KolynVersion string                     `json:"kolyn_version"`
+	Entries      map[string]SkillIndexEntry `json:"entries"`
+}
+
+// SkillIndexEntry guarda los datos de un archivo de skill ya parseado
+type SkillIndexEntry struct {
+	Path        string            `json:"path"`
+	ModTime     int64             `json:"mtime"`
+	Size        int64             `json:"size"`
+	Hash        string            `json:"hash"`
+	Description string            `json:"description,omitempty"`
+	Frontmatter *SkillFrontmatter `json:"frontmatter,omitempty"`
+}
+
+// skillFile es un archivo .md encontrado en un directorio de skills
+type skillFile struct {
+	Path    string
+	RelPath string // Relativa al directorio de skills, con "/"
+	Entry   SkillIndexEntry
+}
+
+// newIndexedSkill construye la skill de un archivo; relPath (sin sufijo de idioma) define ID, nombre y categoría
+func newIndexedSkill(source, relPath string, f skillFile) indexedSkill {
+	category := path.Dir(relPath)
+	if category == "." {
+		category = "root"
+	}
+
+	item := indexedSkill{
+		Info: SkillInfo{
+			ID:          skillID(source, relPath),
+			Name:        strings.TrimSuffix(path.Base(relPath), ".md"),
+			Source:      source,
+			Category:    category,
+			Path:        f.Path,
+			RelPath:     relPath,
+			Dir:         filepath.Clean(strings.TrimSuffix(f.Path, filepath.FromSlash(f.RelPath))),
+			Description: f.Entry.Description,
+		},
+	}
+	if f.Entry.Frontmatter != nil {
+		item.FM = *f.Entry.Frontmatter
+		if item.FM.ID != "" {
+			item.Info.ID = item.FM.ID
+		}
+		item.Info.Capability = item.FM.Capability
+		item.Info.AppliesTo = item.FM.AppliesTo
+		item.Info.Extends = item.FM.Extends
+	}
+	return item
+}
+
+// indexedSkill es una skill con su frontmatter (y el cuerpo, cuando se carga para buscar)
+type indexedSkill struct {
+	Info SkillInfo
+	FM   SkillFrontmatter
+	Body string
+}
+
+func getSkillIndexPath() (string, error) {
+	paths, err := config.GetPaths()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(paths.CacheDir, skillIndexFile), nil
+}
+
+// loadSkillIndex lee la caché; si no existe, está corrupta o es de otra versión devuelve un índice vacío
+func loadSkillIndex() *SkillIndex {
+	empty := &SkillIndex{Version: skillIndexVersion, KolynVersion: Version, Entries: make(map[string]SkillIndexEntry)}
+
+	path, err := getSkillIndexPath()
+	if err != nil {
+		return empty
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+
+	var index SkillIndex
+	if err := json.Unmarshal(data, &index); err != nil || index.Version != skillIndexVersion ||
+		index.KolynVersion != Version || index.Entries == nil {
+		return empty
+	}
+	return &index
+}
+
+// saveSkillIndex escribe la caché de forma atómica (archivo temporal + rename)
+func saveSkillIndex(index *SkillIndex) error {
+	path, err := getSkillIndexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creando directorio de caché: %w", err)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error serializando índice: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), skillIndexFile+".*")
+	if err != nil {
+		return fmt.Errorf("error escribiendo índice: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("error escribiendo índice: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("error escribiendo índice: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// invalidateSkillIndex borra la caché para forzar un escaneo completo (ej. tras kolyn sync)
+func invalidateSkillIndex() error {
+	path, err := getSkillIndexPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// indexEntryFor devuelve la entrada cacheada si sigue vigente o parsea el archivo de nuevo
+func (idx *SkillIndex) indexEntryFor(path string, info fs.FileInfo) (SkillIndexEntry, bool, error) {
+	if cached, ok := idx.Entries[path]; ok && cached.ModTime == info.ModTime().UnixNano() && cached.Size == info.Size() {
+		return cached, false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SkillIndexEntry{}, false, err
+	}
+
+	entry := SkillIndexEntry{
+		Path:        path,
+		ModTime:     info.ModTime().UnixNano(),
+		Size:        info.Size(),
+		Hash:        hashContent(content),
+		Description: getSkillDescriptionFromFile(string(content)),
+	}
+	if fm, err := parseFrontmatter(content); err == nil {
+		entry.Frontmatter = fm
+	}
+	idx.Entries[path] = entry
+	return entry, true, nil
+}
+
+// prune descarta las entradas de archivos que ya no existen. Solo toca los directorios que se
+// escanearon: la caché es compartida y otro proyecto puede tener sources distintos (.kolyn.json).
+func (idx *SkillIndex) prune(scanned []string, seen map[string]bool) bool {
+	changed := false
+	for path := range idx.Entries {
+		if seen[path] {
+			continue
+		}
+		for _, dir := range scanned {
+			if config.IsWithin(dir, path) {
+				delete(idx.Entries, path)
+				changed = true
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// scanSkillIndex recorre ~/.kolyn/skills y los sources usando la caché persistente:
+// solo se vuelven a leer los archivos cuyo mtime o tamaño cambió.
+func scanSkillIndex(ctx context.Context) ([]indexedSkill, error) {
+	skillDirs, err := getSkillsDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	index := loadSkillIndex()
+	seen := make(map[string]bool)
+	changed := false
+
+	var skills []indexedSkill
+	var scanned []string
+	for _, dir := range skillDirs {
+		baseDir := dir.Path
+		scanned = append(scanned, baseDir)
+		if _, err := os.Stat(baseDir); os.IsNotExist(err) {
+			continue
+		}
+		source := dir.Source
+
+		var files []skillFile
+
+		err := filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Skip errors accessing files
+			}
+
+			// Check context cancellation
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			if d.IsDir() {
+				if d.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(d.Name(), ".md") || d.Name() == "README.md" {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			entry, updated, err := index.indexEntryFor(path, info)
+			if err != nil {
+				return nil // Skip unreadable files
+			}
+			seen[path] = true
+			changed = changed || updated
+
+			relPath, _ := filepath.Rel(baseDir, path)
+			files = append(files, skillFile{Path: path, RelPath: filepath.ToSlash(relPath), Entry: entry})
+			return nil
+		})
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+
+		// Las variantes por idioma (core.es.md, core.en.md) forman una sola skill
+		skills = append(skills, groupSkillTranslations(source, files)...)
+	}
+
+	if index.prune(scanned, seen) {
+		changed = true
+	}
+
+	if changed {
+		// La caché es solo una optimización: si no se puede guardar se sigue sin ella
+		_ = saveSkillIndex(index)
+	}
+	return skills, nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSkillIndexPrune(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []string
+		scanned     []string
+		seen        []string
+		wantEntries []string
+		wantChanged bool
+	}{
+		{
+			name:        "borra lo que ya no existe en un directorio escaneado",
+			entries:     []string{"/a/skills/core.md", "/a/skills/old.md"},
+			scanned:     []string{"/a/skills"},
+			seen:        []string{"/a/skills/core.md"},
+			wantEntries: []string{"/a/skills/core.md"},
+			wantChanged: true,
+		},
+		{
+			name:        "conserva las entradas de sources de otro proyecto",
+			entries:     []string{"/a/skills/core.md", "/b/skills/db.md"},
+			scanned:     []string{"/a/skills"},
+			seen:        []string{"/a/skills/core.md"},
+			wantEntries: []string{"/a/skills/core.md", "/b/skills/db.md"},
+		},
+		{
+			name:        "un prefijo igual no es el mismo directorio",
+			entries:     []string{"/a/skills-extra/x.md"},
+			scanned:     []string{"/a/skills"},
+			wantEntries: []string{"/a/skills-extra/x.md"},
+		},
+		{
+			name:        "directorio escaneado que ya no existe",
+			entries:     []string{"/a/skills/core.md"},
+			scanned:     []string{"/a/skills"},
+			wantEntries: []string{},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := &SkillIndex{Entries: make(map[string]SkillIndexEntry)}
+			for _, p := range tt.entries {
+				index.Entries[filepath.FromSlash(p)] = SkillIndexEntry{Path: p}
+			}
+			seen := make(map[string]bool)
+			for _, p := range tt.seen {
+				seen[filepath.FromSlash(p)] = true
+			}
+			var scanned []string
+			for _, p := range tt.scanned {
+				scanned = append(scanned, filepath.FromSlash(p))
+			}
+
+			changed := index.prune(scanned, seen)
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			got := []string{}
+			for p := range index.Entries {
+				got = append(got, filepath.ToSlash(p))
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.wantEntries) {
+				t.Fatalf("entries = %q, want %q", got, tt.wantEntries)
+			}
+			for i := range got {
+				if got[i] != tt.wantEntries[i] {
+					t.Errorf("entries = %q, want %q", got, tt.wantEntries)
+				}
+			}
+		})
+	}
+}
+
+func TestSkillIndexEntryFor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "core.md")
+	write := func(content string, mtime time.Time) os.FileInfo {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info
+	}
+	base := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		content     string
+		mtime       time.Time
+		wantUpdated bool
+		wantName    string
+	}{
+		{name: "primera lectura", content: "---\nname: uno\n---\n", mtime: base, wantUpdated: true, wantName: "uno"},
+		{name: "sin cambios usa la caché", content: "---\nname: uno\n---\n", mtime: base, wantName: "uno"},
+		{name: "cambia el mtime", content: "---\nname: dos\n---\n", mtime: base.Add(time.Minute), wantUpdated: true, wantName: "dos"},
+		{name: "cambia el tamaño", content: "---\nname: tres!\n---\n", mtime: base.Add(time.Minute), wantUpdated: true, wantName: "tres!"},
+	}
+
+	index := &SkillIndex{Entries: make(map[string]SkillIndexEntry)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := write(tt.content, tt.mtime)
+			entry, updated, err := index.indexEntryFor(path, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("updated = %v, want %v", updated, tt.wantUpdated)
+			}
+			if entry.Frontmatter == nil || entry.Frontmatter.Name != tt.wantName {
+				t.Errorf("frontmatter = %+v, want name %s", entry.Frontmatter, tt.wantName)
+			}
+		})
+	}
+}
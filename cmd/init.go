@@ -2,70 +2,106 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
+var initOpts = InitOptions{Interactive: true}
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Inicializa kolyn y genera Agent.md",
-	Long:  `Analiza el proyecto, copia las skills seleccionadas a .kolyn/skills/ y genera un archivo Agent.md con reglas inyectadas.`,
+	Long: `Analiza el proyecto, copia las skills seleccionadas a .kolyn/skills/ y genera un archivo Agent.md con reglas inyectadas.
+
+Si el proyecto tiene .kolyn.json (se busca subiendo desde el directorio actual) se usan sus
+sources, sus "targets" (archivos de contexto a generar) y sus "required_skills", que se
+vendorizan siempre.
+
+Con --profile aplica un perfil del repo de skills (profiles/<nombre>.yml) sin abrir el selector;
+agrega --select para revisarlo en el selector con las skills del perfil ya marcadas.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cwd, err := os.Getwd()
+		root, err := currentProjectRoot()
 		if err != nil {
-			return fmt.Errorf("error obteniendo directorio actual: %w", err)
+			return err
 		}
-		return RunInitProject(cmd.Context(), cwd, true)
+		return RunInitProject(cmd.Context(), root, initOpts)
 	},
 }
 
+func init() {
+	initCmd.Flags().StringVarP(&initOpts.Profile, "profile", "p", "", "Aplica un perfil de skills (ver 'kolyn skills profiles')")
+	initCmd.Flags().BoolVar(&initOpts.Select, "select", false, "Con --profile, abre el selector con las skills del perfil marcadas")
+}
+
+// InitOptions controla cómo kolyn init elige las skills
+type InitOptions struct {
+	Interactive bool   // Preguntar (selector, confirmaciones y variables)
+	Profile     string // Perfil de skills a aplicar
+	Select      bool   // Mostrar el selector con el perfil pre-seleccionado en lugar de aplicarlo directo
+}
+
 // Internal struct to hold skill data during init process
 type SelectedSkillData struct {
+	ID           string // Identificador canónico (source/category/name)
 	OriginalPath string
 	LocalPath    string // Path relative to project root (e.g. .kolyn/skills/foo.md)
 	Name         string
 	Category     string
 	Rules        []string
-}
-
-// SkillFrontmatter structure reused for extraction
-type SkillFrontmatterInit struct {
-	Name       string   `yaml:"name"`
-	AgentRules []string `yaml:"agent_rules"`
+	Extends      []string // IDs de las skills padre
 }
 
 // RunInitProject initializes a project at the given root directory.
-func RunInitProject(ctx context.Context, root string, interactive bool) error {
+func RunInitProject(ctx context.Context, root string, opts InitOptions) error {
+	interactive := opts.Interactive
+
+	// El idioma configurado elige la variante de las skills traducidas
+	globalCfg, _ := config.LoadGlobalConfig()
+	if globalCfg != nil && globalCfg.Language != "" {
+		ui.CurrentLanguage = globalCfg.Language
+	}
 	ui.ShowSection("🚀 Inicializando Kolyn")
 
+	// .kolyn.json del proyecto: sources extra, targets y skills requeridas
+	effectiveCfg, projectCfg, err := loadProjectLayer(globalCfg, root)
+	if err != nil {
+		return err
+	}
+	if projectCfg != nil {
+		ui.PrintInfo(ui.GetText("using_local"))
+	}
+	warnStaleSources(effectiveCfg)
+
 	// 1. Detección automática
 	ui.PrintStep("Detectando tipo de proyecto...")
 	pType := detectProjectType(root)
 	ui.Cyan.Printf("   🔍 Tipo base: %s\n\n", strings.ToUpper(pType))
 
-	agentPath := filepath.Join(root, "Agent.md")
+	agentTarget := projectCfg.AgentTargets()[0]
+	agentPath := filepath.Join(root, filepath.FromSlash(agentTarget))
 	var existingSkills map[string]bool
-	var err error
 
 	// 2. Leer skills existentes
 	if exists(agentPath) {
-		ui.PrintInfo("Agent.md existente detectado. Leyendo configuración actual...")
+		ui.PrintInfo("%s existente detectado. Leyendo configuración actual...", agentTarget)
 		existingSkills, err = readExistingSkillsFromAgent(agentPath)
 		if err != nil {
 			ui.PrintWarning(fmt.Sprintf("No se pudieron leer las skills actuales: %v", err))
 		}
-	} else {
+	}
+	if existingSkills == nil {
 		existingSkills = make(map[string]bool)
 	}
 
@@ -77,12 +113,42 @@ func RunInitProject(ctx context.Context, root string, interactive bool) error {
 			return nil
 		}
 	}
+	// Con IDs repetidos gana la skill de mayor prioridad (scanSkills sigue ese orden)
+	allSkills = uniqueSkillsByID(allSkills)
+
+	lock, err := loadSkillsLock(root)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("No se pudo leer %s, se regenerará: %v", skillsLockFile, err))
+		lock = &SkillsLock{}
+	}
+	for _, locked := range lock.Skills {
+		existingSkills[lockedSkillID(locked)] = true
+	}
+
+	// 3.5 Perfil de skills (--profile) y skills requeridas por .kolyn.json: quedan marcadas en el selector
+	var profileSkills []SkillInfo
+	preselectedIDs := make(map[string]bool)
+	if opts.Profile != "" {
+		profileSkills, err = applyProfile(opts.Profile, allSkills, pType)
+		if err != nil {
+			return err
+		}
+		for _, s := range profileSkills {
+			preselectedIDs[s.ID] = true
+		}
+	}
+	requiredSkills := findRequiredSkills(projectCfg, allSkills)
+	for _, s := range requiredSkills {
+		preselectedIDs[s.ID] = true
+	}
 
 	// 4. Selección Interactiva
 	var selectedSkillsRaw []SkillInfo
 
-	if interactive && len(allSkills) > 0 {
-		sort.Slice(allSkills, func(i, j int) bool {
+	if len(profileSkills) > 0 && !(interactive && opts.Select) {
+		selectedSkillsRaw = withSkillParents(withRequiredSkills(profileSkills, requiredSkills), allSkills)
+	} else if interactive && len(allSkills) > 0 {
+		sort.SliceStable(allSkills, func(i, j int) bool {
 			if allSkills[i].Category == allSkills[j].Category {
 				return allSkills[i].Name < allSkills[j].Name
 			}
@@ -98,28 +164,52 @@ func RunInitProject(ctx context.Context, root string, interactive bool) error {
 				label = s.Name
 			}
 
-			isSelected := isSkillSelected(s.Path, existingSkills)
+			isSelected := isSkillSelected(s, existingSkills) || preselectedIDs[s.ID]
 
 			uiOptions = append(uiOptions, ui.SkillOption{
 				Label:       label,
-				Value:       s.Path,
+				Value:       s.ID,
 				Description: s.Description,
 				Selected:    isSelected,
 			})
-			skillMap[s.Path] = s
+			skillMap[s.ID] = s
 		}
 
-		selectedPaths, err := ui.SelectSkills("Selecciona las skills para este proyecto:", uiOptions)
+		selectedIDs, err := ui.SelectSkills("Selecciona las skills para este proyecto:", uiOptions)
 		if err != nil {
 			return nil // Cancelado
 		}
 
-		for _, path := range selectedPaths {
-			if skill, ok := skillMap[path]; ok {
+		for _, id := range selectedIDs {
+			if skill, ok := skillMap[id]; ok {
 				selectedSkillsRaw = append(selectedSkillsRaw, skill)
 			}
 		}
 
+		// Las skills requeridas se vendorizan aunque se hayan desmarcado
+		selectedSkillsRaw = withSkillParents(withRequiredSkills(selectedSkillsRaw, requiredSkills), allSkills)
+
+		// 4.5 Skills que estaban activas y fueron desmarcadas
+		deselected := findDeselectedSkills(root, uiOptions, skillMap, selectedSkillsRaw)
+		if len(deselected) > 0 {
+			ui.PrintWarning("Se desmarcaron %d skills que ya estaban en el proyecto:", len(deselected))
+			for _, localPath := range deselected {
+				ui.Gray.Printf("   - %s\n", localPath)
+			}
+			if ui.AskYesNo("¿Eliminarlas de .kolyn/skills/?") {
+				for _, localPath := range deselected {
+					if err := removeLocalSkill(root, localPath); err != nil {
+						ui.PrintError("Fallo al eliminar skill %s: %v", localPath, err)
+						continue
+					}
+					lock.remove(toLocalSkillPath(root, localPath))
+					ui.Gray.Printf("   🗑️  %s\n", localPath)
+				}
+			}
+		}
+
+	} else if len(requiredSkills) > 0 {
+		selectedSkillsRaw = withSkillParents(withRequiredSkills(nil, requiredSkills), allSkills)
 	} else if len(allSkills) > 0 {
 		ui.PrintInfo("Modo no interactivo: No se seleccionaron skills adicionales.")
 	}
@@ -133,13 +223,52 @@ func RunInitProject(ctx context.Context, root string, interactive bool) error {
 			return fmt.Errorf("error creando directorio de skills: %w", err)
 		}
 
+		vars, err := newProjectVars(root, interactive)
+		if err != nil {
+			return err
+		}
+
 		for _, skill := range selectedSkillsRaw {
-			localPath, _, err := copySkillToProject(skill.Path, root, skillsDestDir)
+			source := skill
+			if len(skill.Translations) > 0 {
+				var found bool
+				if source, found = localizeSkill(skill, ui.CurrentLanguage); !found {
+					ui.Gray.Printf("   ℹ️  %s no tiene versión '%s', se usa %s\n", skill.Name, ui.CurrentLanguage, filepath.Base(source.Path))
+				}
+			}
+
+			localPath, _, err := copySkillToProject(source, root, skillsDestDir, vars)
 			if err != nil {
 				ui.PrintError("Fallo al copiar skill %s: %v", skill.Name, err)
 				continue
 			}
 			ui.Gray.Printf("   ✅ %s -> %s\n", skill.Name, localPath)
+
+			if legacyPath := removeLegacyFlatCopy(root, skill, localPath, lock); legacyPath != "" {
+				ui.Gray.Printf("   🗑️  %s (copia plana anterior)\n", legacyPath)
+			}
+
+			entry, err := newLockedSkill(ctx, source, localPath)
+			if err != nil {
+				ui.PrintWarning(fmt.Sprintf("No se pudo registrar %s en %s: %v", skill.Name, skillsLockFile, err))
+				continue
+			}
+			if hash, err := hashFile(filepath.Join(root, filepath.FromSlash(localPath))); err == nil && hash != entry.Hash {
+				entry.RenderedHash = hash // Copia renderizada con las variables del proyecto
+			}
+			lock.upsert(entry)
+		}
+
+		if err := vars.save(); err != nil {
+			ui.PrintWarning(err.Error())
+		}
+	}
+
+	// 5.1 Registrar procedencia en .kolyn/skills.lock
+	lock.prune(root)
+	if len(lock.Skills) > 0 || exists(getSkillsLockPath(root)) {
+		if err := saveSkillsLock(root, lock); err != nil {
+			ui.PrintWarning(fmt.Sprintf("No se pudo escribir %s: %v", skillsLockFile, err))
 		}
 	}
 
@@ -155,10 +284,11 @@ func RunInitProject(ctx context.Context, root string, interactive bool) error {
 	}
 
 	ui.Separator()
+	targets := strings.Join(projectCfg.AgentTargets(), ", ")
 	if len(selectedSkillsRaw) > 0 {
-		ui.PrintSuccess("✅ Agent.md actualizado con nuevas skills.")
+		ui.PrintSuccess("✅ %s actualizado con nuevas skills.", targets)
 	} else {
-		ui.PrintSuccess("✅ Agent.md regenerado/verificado.")
+		ui.PrintSuccess("✅ %s regenerado/verificado.", targets)
 	}
 	ui.Gray.Printf("   Total skills activas: %d\n", len(allLocalSkills))
 	ui.Gray.Println("Ahora el proyecto es autónomo. Las skills viven en .kolyn/skills/")
@@ -166,113 +296,384 @@ func RunInitProject(ctx context.Context, root string, interactive bool) error {
 	return nil
 }
 
-// loadAllLocalSkills lee todas las skills en .kolyn/skills para reconstruir el estado completo
+// uniqueSkillsByID deja la primera skill de cada ID. skills debe venir en el orden de
+// getSkillsDirs (~/.kolyn/skills y luego los sources por prioridad) para que gane la de mayor prioridad.
+func uniqueSkillsByID(skills []SkillInfo) []SkillInfo {
+	seen := make(map[string]bool, len(skills))
+	unique := make([]SkillInfo, 0, len(skills))
+	for _, s := range skills {
+		if seen[s.ID] {
+			ui.PrintWarning("ID de skill duplicado '%s', se ignora %s", s.ID, s.Path)
+			continue
+		}
+		seen[s.ID] = true
+		unique = append(unique, s)
+	}
+	return unique
+}
+
+// withSkillParents agrega las skills padre (extends) para vendorizarlas junto a sus hijas
+func withSkillParents(selected, available []SkillInfo) []SkillInfo {
+	withParents, missing := expandSkillParents(selected, available)
+	for _, parent := range withParents[len(selected):] {
+		ui.Gray.Printf("   ➕ %s (requerida por extends)\n", parent.ID)
+	}
+	for _, id := range missing {
+		ui.PrintWarning("No se encontró la skill padre '%s'. Ejecuta 'kolyn sync'.", id)
+	}
+	return withParents
+}
+
+// findRequiredSkills busca las skills de required_skills en .kolyn.json; avisa de las que no existen
+func findRequiredSkills(projectCfg *config.ProjectConfig, available []SkillInfo) []SkillInfo {
+	if projectCfg == nil {
+		return nil
+	}
+
+	byID := make(map[string]SkillInfo, len(available))
+	for _, s := range available {
+		if _, dup := byID[s.ID]; !dup {
+			byID[s.ID] = s
+		}
+	}
+
+	var required []SkillInfo
+	for _, id := range projectCfg.RequiredSkills {
+		skill, ok := byID[id]
+		if !ok {
+			ui.PrintWarning("No se encontró la skill requerida '%s' (%s). Ejecuta 'kolyn sync'.", id, config.ProjectConfigFile)
+			continue
+		}
+		required = append(required, skill)
+	}
+	return required
+}
+
+// withRequiredSkills agrega a la selección las skills requeridas que falten
+func withRequiredSkills(selected, required []SkillInfo) []SkillInfo {
+	included := make(map[string]bool, len(selected))
+	for _, s := range selected {
+		included[s.ID] = true
+	}
+
+	result := append([]SkillInfo(nil), selected...)
+	for _, s := range required {
+		if included[s.ID] {
+			continue
+		}
+		included[s.ID] = true
+		result = append(result, s)
+		ui.Gray.Printf("   📌 %s (requerida por %s)\n", s.ID, config.ProjectConfigFile)
+	}
+	return result
+}
+
+// loadAllLocalSkills lee todas las skills en .kolyn/skills (recursivo) para reconstruir el estado completo
 func loadAllLocalSkills(root string) ([]SelectedSkillData, error) {
 	skillsDir := filepath.Join(root, ".kolyn", "skills")
 	var results []SelectedSkillData
 
-	entries, err := os.ReadDir(skillsDir)
-	if os.IsNotExist(err) {
+	if _, err := os.Stat(skillsDir); os.IsNotExist(err) {
 		return results, nil
 	}
-	if err != nil {
-		return nil, err
-	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
-			continue
+	// El lock conserva la categoría original de las copias planas (versiones anteriores)
+	lock, _ := loadSkillsLock(root)
+
+	err := filepath.WalkDir(skillsDir, func(fullPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) != ".md" {
+			return nil
 		}
 
-		fullPath := filepath.Join(skillsDir, entry.Name())
 		content, err := os.ReadFile(fullPath)
 		if err != nil {
-			continue
+			return nil
 		}
 
 		// Parse Frontmatter
-		var name string = strings.TrimSuffix(entry.Name(), ".md")
-		var rules []string
-		var category string = "Installed" // Default category since folder structure is flattened
-
-		if bytes.HasPrefix(content, []byte("---")) {
-			parts := bytes.SplitN(content, []byte("---"), 3)
-			if len(parts) >= 3 {
-				var fm SkillFrontmatterInit
-				if err := yaml.Unmarshal(parts[1], &fm); err == nil {
-					if fm.Name != "" {
-						name = fm.Name
-					}
-					rules = fm.AgentRules
-				}
+		var name string = strings.TrimSuffix(d.Name(), ".md")
+		var rules, extends []string
+		var id string
+
+		if fm, err := parseFrontmatter(content); err == nil {
+			if fm.Name != "" {
+				name = fm.Name
 			}
+			rules = fm.AgentRules
+			if len(fm.Extends) > 0 {
+				// Sin resolver la herencia, las entradas "!valor" no son reglas
+				rules = mergeInherited(nil, fm.AgentRules)
+			}
+			extends = fm.Extends
+			id = fm.ID
 		}
 
-		relPath, _ := filepath.Rel(root, fullPath)
-		relPath = "./" + filepath.ToSlash(relPath)
+		localPath := toLocalSkillPath(root, fullPath)
+		relPath, _ := filepath.Rel(skillsDir, fullPath)
+		category := filepath.ToSlash(filepath.Dir(relPath))
+
+		var locked *LockedSkill
+		if lock != nil {
+			locked = lock.find(localPath)
+		}
+		if locked != nil {
+			id = lockedSkillID(*locked)
+		}
+		if id == "" {
+			id = skillID("project", relPath)
+		}
+		if category == "." {
+			category = "root"
+			if locked != nil && path.Dir(locked.Path) != "." {
+				category = path.Dir(locked.Path)
+			}
+		}
 
 		results = append(results, SelectedSkillData{
+			ID:           id,
 			OriginalPath: fullPath,
-			LocalPath:    relPath,
+			LocalPath:    localPath,
 			Name:         name,
 			Category:     category,
 			Rules:        rules,
+			Extends:      extends,
 		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return results, nil
 }
 
-// copySkillToProject copia el archivo, extrae reglas y devuelve el path relativo
-func copySkillToProject(srcPath, root, destDir string) (string, []string, error) {
-	content, err := os.ReadFile(srcPath)
+// resolveLocalSkillRules aplica la herencia (extends) a las reglas de las skills vendorizadas.
+// Los padres se buscan por ID entre las mismas skills del proyecto.
+func resolveLocalSkillRules(skills []SelectedSkillData) {
+	paths := make(map[string]string, len(skills))
+	for _, s := range skills {
+		paths[s.ID] = s.OriginalPath
+	}
+	resolver := newSkillResolver(paths)
+
+	for i, s := range skills {
+		if len(s.Extends) == 0 {
+			continue
+		}
+		fm, err := parseSkillFrontmatter(s.OriginalPath)
+		if err != nil {
+			continue
+		}
+		resolved, err := resolver.resolve(s.ID, fm)
+		if err != nil {
+			ui.PrintWarning("%s: %v", s.LocalPath, err)
+			continue
+		}
+		skills[i].Rules = resolved.AgentRules
+	}
+}
+
+// extendedSkillIDs devuelve los IDs que alguna skill activa extiende: sus reglas ya llegan
+// (con los overrides aplicados) a través de la skill hija
+func extendedSkillIDs(skills []SelectedSkillData) map[string]bool {
+	extended := make(map[string]bool)
+	for _, s := range skills {
+		for _, parent := range s.Extends {
+			extended[parent] = true
+		}
+	}
+	return extended
+}
+
+// vendoredSkillPath calcula dónde vive la copia de una skill dentro de .kolyn/skills,
+// conservando los directorios de categoría del source (ej. backend/go/core.md)
+func vendoredSkillPath(destDir string, skill SkillInfo) string {
+	if skill.RelPath == "" {
+		return filepath.Join(destDir, filepath.Base(skill.Path))
+	}
+	return filepath.Join(destDir, filepath.FromSlash(skill.RelPath))
+}
+
+// copySkillToProject copia el archivo (renderizando sus variables), extrae reglas y devuelve el path relativo
+func copySkillToProject(skill SkillInfo, root, destDir string, vars *projectVars) (string, []string, error) {
+	content, err := os.ReadFile(skill.Path)
 	if err != nil {
 		return "", nil, err
 	}
+	if content, err = vars.render(content); err != nil {
+		return "", nil, err
+	}
 
 	var rules []string
-	if bytes.HasPrefix(content, []byte("---")) {
-		parts := bytes.SplitN(content, []byte("---"), 3)
-		if len(parts) >= 3 {
-			var fm SkillFrontmatterInit
-			if err := yaml.Unmarshal(parts[1], &fm); err == nil {
-				rules = fm.AgentRules
-			}
-		}
+	if fm, err := parseFrontmatter(content); err == nil {
+		rules = fm.AgentRules
 	}
 
-	baseName := filepath.Base(srcPath)
-	destPath := filepath.Join(destDir, baseName)
+	destPath := vendoredSkillPath(destDir, skill)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return "", nil, err
+	}
 
 	if err := os.WriteFile(destPath, content, 0644); err != nil {
 		return "", nil, err
 	}
 
-	relPath, err := filepath.Rel(root, destPath)
+	return toLocalSkillPath(root, destPath), rules, nil
+}
+
+// removeLegacyFlatCopy elimina la copia plana (.kolyn/skills/<archivo>.md) que dejaban versiones
+// anteriores cuando la misma skill ya se vendorizó con su categoría. Devuelve la ruta eliminada.
+func removeLegacyFlatCopy(root string, skill SkillInfo, localPath string, lock *SkillsLock) string {
+	flatPath := filepath.Join(root, ".kolyn", "skills", filepath.Base(skill.Path))
+	flatLocal := toLocalSkillPath(root, flatPath)
+	if flatLocal == localPath || !exists(flatPath) {
+		return ""
+	}
+
+	if locked := lock.find(flatLocal); locked != nil {
+		if locked.Path != skill.RelPath {
+			return "" // Es otra skill con el mismo nombre de archivo
+		}
+	} else {
+		flatHash, err := hashFile(flatPath)
+		if err != nil {
+			return ""
+		}
+		newHash, err := hashFile(filepath.Join(root, filepath.FromSlash(localPath)))
+		if err != nil || flatHash != newHash {
+			return ""
+		}
+	}
+
+	if err := removeLocalSkill(root, flatPath); err != nil {
+		return ""
+	}
+	lock.remove(flatLocal)
+	return flatLocal
+}
+
+// findDeselectedSkills devuelve las rutas locales de skills que estaban pre-seleccionadas
+// (vendorizadas en el proyecto) y que el usuario desmarcó en el selector.
+func findDeselectedSkills(root string, options []ui.SkillOption, skillMap map[string]SkillInfo, selected []SkillInfo) []string {
+	skillsDir := filepath.Join(root, ".kolyn", "skills")
+
+	keep := make(map[string]bool)
+	for _, s := range selected {
+		keep[vendoredSkillPath(skillsDir, s)] = true
+		keep[filepath.Join(skillsDir, filepath.Base(s.Path))] = true
+	}
+
+	var deselected []string
+	seen := make(map[string]bool)
+	for _, opt := range options {
+		skill, ok := skillMap[opt.Value]
+		if !opt.Selected || !ok {
+			continue
+		}
+
+		// La copia puede estar con su categoría o en formato plano (versiones anteriores)
+		candidates := []string{vendoredSkillPath(skillsDir, skill), filepath.Join(skillsDir, filepath.Base(skill.Path))}
+		for _, localPath := range candidates {
+			if keep[localPath] || seen[localPath] || !exists(localPath) {
+				continue
+			}
+			seen[localPath] = true
+			deselected = append(deselected, localPath)
+			break
+		}
+	}
+	return deselected
+}
+
+// removeLocalSkill elimina una skill vendorizada en .kolyn/skills/ del proyecto
+// junto con los directorios de categoría que queden vacíos.
+func removeLocalSkill(root, localPath string) error {
+	skillsDir := filepath.Join(root, ".kolyn", "skills")
+	rel, err := filepath.Rel(skillsDir, localPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("la ruta %s no pertenece a .kolyn/skills", localPath)
+	}
+	if err := os.Remove(localPath); err != nil {
+		return err
+	}
+
+	for dir := filepath.Dir(localPath); dir != skillsDir; dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			break // No está vacío
+		}
+	}
+	return nil
+}
+
+// findLocalSkill busca una skill vendorizada por ID, nombre de archivo, nombre en frontmatter o ruta relativa.
+func findLocalSkill(root, name string) (*SelectedSkillData, error) {
+	localSkills, err := loadAllLocalSkills(root)
 	if err != nil {
-		relPath = destPath
+		return nil, err
 	}
 
-	relPath = filepath.ToSlash(relPath)
-	if !strings.HasPrefix(relPath, ".") {
-		relPath = "./" + relPath
+	needle := strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(name), "./"), ".md")
+	var matches []*SelectedSkillData
+	for i, s := range localSkills {
+		local := strings.TrimSuffix(strings.TrimPrefix(s.LocalPath, "./"), ".md")
+		if s.ID == name || local == needle || strings.TrimPrefix(local, ".kolyn/skills/") == needle {
+			return &localSkills[i], nil // Ruta exacta, no hay ambigüedad
+		}
+		if s.Name == needle || strings.TrimSuffix(filepath.Base(s.OriginalPath), ".md") == needle {
+			matches = append(matches, &localSkills[i])
+		}
 	}
 
-	return relPath, rules, nil
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("skill '%s' no encontrada en .kolyn/skills/", name)
+	case 1:
+		return matches[0], nil
+	default:
+		var paths []string
+		for _, m := range matches {
+			paths = append(paths, strings.TrimPrefix(m.LocalPath, "./.kolyn/skills/"))
+		}
+		return nil, fmt.Errorf("'%s' es ambiguo, usa la ruta completa: %s", name, strings.Join(paths, ", "))
+	}
 }
 
-func isSkillSelected(skillPath string, existing map[string]bool) bool {
-	if existing[skillPath] {
+// isSkillSelected indica si una skill disponible ya está referenciada en Agent.md.
+// Compara la ruta relativa dentro del source; las copias planas antiguas se comparan por nombre de archivo.
+func isSkillSelected(skill SkillInfo, existing map[string]bool) bool {
+	if existing[skill.ID] || existing[skill.Path] {
 		return true
 	}
-	base := filepath.Base(skillPath)
-	for k := range existing {
-		if filepath.Base(k) == base {
+	for link := range existing {
+		rel := skillRelPathFromLink(link)
+		if rel == skill.RelPath {
+			return true
+		}
+		if !strings.Contains(rel, "/") && rel == filepath.Base(skill.Path) {
 			return true
 		}
 	}
 	return false
 }
 
+// skillRelPathFromLink extrae la ruta relativa al source de un link de Agent.md
+// (./.kolyn/skills/<rel> o ~/.kolyn/sources/<source>/<rel>)
+func skillRelPathFromLink(link string) string {
+	link = filepath.ToSlash(link)
+	if idx := strings.Index(link, ".kolyn/skills/"); idx >= 0 {
+		return link[idx+len(".kolyn/skills/"):]
+	}
+	if idx := strings.Index(link, ".kolyn/sources/"); idx >= 0 {
+		rest := link[idx+len(".kolyn/sources/"):]
+		if slash := strings.Index(rest, "/"); slash >= 0 {
+			return rest[slash+1:]
+		}
+	}
+	return path.Base(link)
+}
+
 func readExistingSkillsFromAgent(path string) (map[string]bool, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
@@ -303,11 +704,34 @@ func readExistingSkillsFromAgent(path string) (map[string]bool, error) {
 				linkPath := matches[1]
 				skills[linkPath] = true
 			}
+			if id := parseAgentSkillID(line); id != "" {
+				skills[id] = true
+			}
 		}
 	}
 	return skills, nil
 }
 
+// agentSkillIDRegex captura el ID canónico anotado en cada línea de "Skills Reference"
+var agentSkillIDRegex = regexp.MustCompile("id: `([^`]+)`")
+
+func parseAgentSkillID(line string) string {
+	matches := agentSkillIDRegex.FindStringSubmatch(line)
+	if len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
+}
+
+// agentSkillLine genera la línea de referencia de una skill en Agent.md
+func agentSkillLine(s SelectedSkillData, category string) string {
+	line := fmt.Sprintf("- [%s (%s)](%s)", s.Name, category, s.LocalPath)
+	if s.ID != "" {
+		line += fmt.Sprintf(" — id: `%s`", s.ID)
+	}
+	return line + "\n"
+}
+
 func detectProjectType(root string) string {
 	if exists(filepath.Join(root, "next.config.ts")) ||
 		exists(filepath.Join(root, "next.config.js")) ||
@@ -332,18 +756,45 @@ func exists(path string) bool {
 	return err == nil
 }
 
+// GenerateAgentMD genera o hidrata los archivos de contexto del proyecto: Agent.md o los
+// "targets" de .kolyn.json (ej. Agent.md y CLAUDE.md), todos con el mismo contenido.
 func GenerateAgentMD(root string, pType string, skills []SelectedSkillData) error {
-	agentPath := filepath.Join(root, "Agent.md")
+	// Un .kolyn.json inválido ya se reportó al cargar la configuración; aquí se usan los valores por defecto
+	projectCfg, _, _ := config.FindProjectConfig(root)
+	projectName := filepath.Base(root)
+	if projectCfg != nil && projectCfg.ProjectName != "" {
+		projectName = projectCfg.ProjectName
+	}
 
-	// Generar el contenido de las secciones dinámicas
-	var skillsBlock strings.Builder
-	var rulesBlock strings.Builder
+	// Las reglas de cada skill incluyen las heredadas de sus padres (extends)
+	resolveLocalSkillRules(skills)
 
 	// Ordenar skills por nombre para consistencia
 	sort.Slice(skills, func(i, j int) bool {
 		return skills[i].Name < skills[j].Name
 	})
 
+	for _, target := range projectCfg.AgentTargets() {
+		agentPath, err := config.TargetPath(root, target)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(agentPath), 0755); err != nil {
+			return fmt.Errorf("error creando directorio de %s: %w", target, err)
+		}
+		if err := generateAgentFile(agentPath, projectName, pType, skills); err != nil {
+			return fmt.Errorf("error escribiendo %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// generateAgentFile escribe un archivo de contexto; si ya existe solo reemplaza las secciones de skills y reglas
+func generateAgentFile(agentPath, projectName, pType string, skills []SelectedSkillData) error {
+	// Generar el contenido de las secciones dinámicas
+	var skillsBlock strings.Builder
+	var rulesBlock strings.Builder
+
 	if len(skills) > 0 {
 		skillsBlock.WriteString("\nThe following skills are active for this project.\n\n")
 		for _, s := range skills {
@@ -352,22 +803,13 @@ func GenerateAgentMD(root string, pType string, skills []SelectedSkillData) erro
 			if cat == "" {
 				cat = "Skill"
 			}
-			skillsBlock.WriteString(fmt.Sprintf("- [%s (%s)](%s)\n", s.Name, cat, s.LocalPath))
+			skillsBlock.WriteString(agentSkillLine(s, cat))
 		}
 	} else {
 		skillsBlock.WriteString("\n⚠️ No skills selected. Run 'kolyn init' again to add skills.\n")
 	}
 
-	ruleCounter := 1
-	for _, s := range skills {
-		if len(s.Rules) > 0 {
-			rulesBlock.WriteString(fmt.Sprintf("\n#### From %s:\n", s.Name))
-			for _, r := range s.Rules {
-				rulesBlock.WriteString(fmt.Sprintf("%d. %s\n", ruleCounter, r))
-				ruleCounter++
-			}
-		}
-	}
+	ruleCounter := writeSkillRules(&rulesBlock, skills)
 	rulesBlock.WriteString("\n#### General:\n")
 	rulesBlock.WriteString(fmt.Sprintf("%d. **Follow the Skills:** Read the reference files above before writing code.\n", ruleCounter))
 	ruleCounter++
@@ -407,8 +849,6 @@ func GenerateAgentMD(root string, pType string, skills []SelectedSkillData) erro
 	}
 
 	// Fallback: Generación desde cero (si no existe o estructura irreconocible)
-	projectName := filepath.Base(root)
-
 	var content strings.Builder
 	fmt.Fprintf(&content, `# Agent Context - %s
 
@@ -437,7 +877,7 @@ Type: %s
 		fmt.Fprintf(&content, "\n### Skills Reference\nThe following skills are active for this project.\n\n")
 
 		for _, s := range skills {
-			fmt.Fprintf(&content, "- [%s (%s)](%s)\n", s.Name, s.Category, s.LocalPath)
+			content.WriteString(agentSkillLine(s, s.Category))
 		}
 	} else {
 		fmt.Fprintf(&content, `
@@ -448,17 +888,7 @@ Type: %s
 
 	fmt.Fprintf(&content, "\n### Rules\n")
 
-	ruleCounter = 1
-
-	for _, s := range skills {
-		if len(s.Rules) > 0 {
-			fmt.Fprintf(&content, "\n#### From %s:\n", s.Name)
-			for _, r := range s.Rules {
-				fmt.Fprintf(&content, "%d. %s\n", ruleCounter, r)
-				ruleCounter++
-			}
-		}
-	}
+	ruleCounter = writeSkillRules(&content, skills)
 
 	fmt.Fprintf(&content, "\n#### General:\n")
 	fmt.Fprintf(&content, "%d. **Follow the Skills:** Read the reference files above before writing code.\n", ruleCounter)
@@ -468,15 +898,46 @@ Type: %s
 	fmt.Fprintf(&content, "%d. **Consistency:** Use the same libraries and patterns defined in the stack.\n", ruleCounter)
 	ruleCounter++
 
-	return os.WriteFile(filepath.Join(root, "Agent.md"), []byte(content.String()), 0644)
+	return os.WriteFile(agentPath, []byte(content.String()), 0644)
+}
+
+// writeSkillRules escribe las reglas numeradas de cada skill y devuelve el siguiente número.
+// Las skills padre se omiten (sus reglas van en la hija) y una regla que ya escribió otra skill no se repite.
+func writeSkillRules(w *strings.Builder, skills []SelectedSkillData) int {
+	ruleCounter := 1
+	seen := make(map[string]bool)
+	extended := extendedSkillIDs(skills)
+	for _, s := range skills {
+		if extended[s.ID] {
+			continue
+		}
+
+		var rules []string
+		for _, r := range s.Rules {
+			if !seen[r] {
+				seen[r] = true
+				rules = append(rules, r)
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n#### From %s:\n", s.Name)
+		for _, r := range rules {
+			fmt.Fprintf(w, "%d. %s\n", ruleCounter, r)
+			ruleCounter++
+		}
+	}
+	return ruleCounter
 }
 
 func getFirstSkillsSourceDir() (string, error) {
-	home, err := os.UserHomeDir()
+	paths, err := config.GetPaths()
 	if err != nil {
 		return "", err
 	}
-	sourcesDir := filepath.Join(home, ".kolyn", "sources")
+	sourcesDir := paths.SourcesDir()
 
 	entries, err := os.ReadDir(sourcesDir)
 	if err != nil {
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+)
+
+func TestUniqueSkillsByID(t *testing.T) {
+	tests := []struct {
+		name        string
+		skills      []SkillInfo
+		wantSources []string
+	}{
+		{
+			name: "sin duplicados",
+			skills: []SkillInfo{
+				{ID: "a/core", Source: "a"},
+				{ID: "b/core", Source: "b"},
+			},
+			wantSources: []string{"a", "b"},
+		},
+		{
+			name: "gana la primera aunque su nombre ordene después",
+			skills: []SkillInfo{
+				{ID: "shared/core", Source: "high", Name: "zz-core", Category: "z"},
+				{ID: "shared/core", Source: "low", Name: "aa-core", Category: "a"},
+			},
+			wantSources: []string{"high"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range uniqueSkillsByID(tt.skills) {
+				got = append(got, s.Source)
+			}
+			if len(got) != len(tt.wantSources) {
+				t.Fatalf("got %q, want %q", got, tt.wantSources)
+			}
+			for i := range got {
+				if got[i] != tt.wantSources[i] {
+					t.Errorf("got %q, want %q", got, tt.wantSources)
+				}
+			}
+		})
+	}
+}
+
+// Dos sources con una skill del mismo ID: gana la del source de mayor prioridad,
+// sin importar el orden alfabético en el que se muestran
+func TestScanSkillsDuplicateIDFollowsPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities map[string]int
+		want       string
+	}{
+		{name: "prioridad al source 'a'", priorities: map[string]int{"a": 10, "b": 1}, want: "a"},
+		{name: "prioridad al source 'b'", priorities: map[string]int{"a": 1, "b": 10}, want: "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv(config.EnvKolynHome, home)
+			t.Chdir(t.TempDir())
+
+			cfg := &config.GlobalConfig{Language: "es"}
+			for _, name := range []string{"a", "b"} {
+				dir := filepath.Join(home, "sources", name, name+"-category")
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				content := "---\nid: shared/core\nname: core-" + name + "\n---\n# Core\n"
+				if err := os.WriteFile(filepath.Join(dir, "core.md"), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+				cfg.SkillsSources = append(cfg.SkillsSources, config.SkillSource{Name: name, URL: "git@example.com:" + name + ".git", Priority: tt.priorities[name]})
+			}
+			if err := config.SaveGlobalConfig(cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			skills, err := scanSkills(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			skills = uniqueSkillsByID(skills)
+			sort.SliceStable(skills, func(i, j int) bool { return skills[i].Name < skills[j].Name })
+
+			if len(skills) != 1 {
+				t.Fatalf("got %d skills, want 1", len(skills))
+			}
+			if skills[0].Source != tt.want {
+				t.Errorf("source = %s, want %s", skills[0].Source, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,570 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	skillsLintFormat string
+	skillsLintStrict bool
+)
+
+var skillsLintCmd = &cobra.Command{
+	Use:   "lint [path...]",
+	Short: "Valida el frontmatter de las skills",
+	Long: `Revisa el frontmatter de cada skill (claves desconocidas, tipos, reglas vacías,
+regex y globs inválidos, valores de applies_to y descripción) en los directorios o archivos indicados.
+Sin argumentos revisa ~/.kolyn/skills y todos los sources sincronizados.
+
+Termina con código distinto de cero si encuentra errores (o advertencias con --strict).`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSkillsLint(args, skillsLintFormat, skillsLintStrict)
+	},
+}
+
+func init() {
+	skillsLintCmd.Flags().StringVar(&skillsLintFormat, "format", "text", "Formato de salida: text o json")
+	skillsLintCmd.Flags().BoolVar(&skillsLintStrict, "strict", false, "Trata las advertencias como errores")
+	skillsCmd.AddCommand(skillsLintCmd)
+}
+
+const (
+	lintError   = "error"
+	lintWarning = "warning"
+)
+
+// LintIssue es un problema encontrado en una skill
+type LintIssue struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// LintReport agrupa el resultado de revisar un conjunto de skills
+type LintReport struct {
+	FilesChecked int         `json:"files_checked"`
+	Errors       int         `json:"errors"`
+	Warnings     int         `json:"warnings"`
+	Issues       []LintIssue `json:"issues"`
+}
+
+type lintKind int
+
+const (
+	lintString lintKind = iota
+	lintStringList
+	lintMap
+	lintMapList
+	lintStringMap
+)
+
+func (k lintKind) String() string {
+	switch k {
+	case lintStringList:
+		return "lista de strings"
+	case lintMap:
+		return "objeto"
+	case lintMapList:
+		return "lista de objetos"
+	case lintStringMap:
+		return "objeto de strings"
+	default:
+		return "string"
+	}
+}
+
+// lintField describe el tipo esperado de una clave del frontmatter
+type lintField struct {
+	Kind     lintKind
+	Fields   map[string]lintField // Para objetos y listas de objetos
+	Enum     []string             // Valores permitidos (error si no coincide)
+	Examples []string             // Valores estándar (advertencia si no coincide)
+}
+
+// skillLintSchema describe todas las claves válidas del frontmatter, derivadas de SkillFrontmatter
+var skillLintSchema = lintSchemaFor(reflect.TypeOf(SkillFrontmatter{}))
+
+// lintSchemaFor convierte un struct del modelo en la tabla de campos que usa el linter
+func lintSchemaFor(t reflect.Type) map[string]lintField {
+	fields := make(map[string]lintField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := yamlFieldName(f)
+		if name == "" {
+			continue
+		}
+
+		field := lintField{
+			Enum:     schemaEnums[f.Tag.Get("enum")],
+			Examples: schemaEnums[f.Tag.Get("examples")],
+		}
+		switch {
+		case f.Type.Kind() == reflect.Struct:
+			field.Kind = lintMap
+			field.Fields = lintSchemaFor(f.Type)
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct:
+			field.Kind = lintMapList
+			field.Fields = lintSchemaFor(f.Type.Elem())
+		case f.Type.Kind() == reflect.Slice:
+			field.Kind = lintStringList
+		case f.Type.Kind() == reflect.Map:
+			field.Kind = lintStringMap
+		default:
+			field.Kind = lintString
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+var (
+	envVarNameRegex    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+)
+
+// runSkillsLint revisa las skills en las rutas indicadas (o en todos los directorios de skills)
+func runSkillsLint(paths []string, format string, strict bool) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("formato inválido '%s' (usa text o json)", format)
+	}
+
+	if len(paths) == 0 {
+		dirs, err := getSkillsDirs()
+		if err != nil {
+			return err
+		}
+		for _, dir := range dirs {
+			paths = append(paths, dir.Path)
+		}
+	}
+
+	report := &LintReport{Issues: []LintIssue{}}
+	for _, p := range paths {
+		if err := lintPath(p, report); err != nil {
+			return err
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		if report.Issues[i].Path == report.Issues[j].Path {
+			return report.Issues[i].Line < report.Issues[j].Line
+		}
+		return report.Issues[i].Path < report.Issues[j].Path
+	})
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error generando JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printLintReport(report)
+	}
+
+	if report.Errors > 0 || (strict && report.Warnings > 0) {
+		return fmt.Errorf("lint falló: %d errores, %d advertencias", report.Errors, report.Warnings)
+	}
+	return nil
+}
+
+// lintPath revisa un archivo o, recursivamente, todas las skills de un directorio
+func lintPath(root string, report *LintReport) error {
+	info, err := os.Stat(root)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		report.add(lintSkillFile(root))
+		report.FilesChecked++
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") || d.Name() == "README.md" {
+			return nil
+		}
+		report.add(lintSkillFile(path))
+		report.FilesChecked++
+		return nil
+	})
+}
+
+func (r *LintReport) add(issues []LintIssue) {
+	for _, issue := range issues {
+		if issue.Severity == lintError {
+			r.Errors++
+		} else {
+			r.Warnings++
+		}
+		r.Issues = append(r.Issues, issue)
+	}
+}
+
+func printLintReport(report *LintReport) {
+	for _, issue := range report.Issues {
+		printer := ui.YellowText
+		if issue.Severity == lintError {
+			printer = ui.RedText
+		}
+		printer.Printf("%s:%d:%d: %s: %s\n", issue.Path, issue.Line, issue.Column, issue.Severity, issue.Message)
+	}
+
+	if len(report.Issues) > 0 {
+		fmt.Println()
+	}
+	summary := fmt.Sprintf("%d archivos revisados, %d errores, %d advertencias", report.FilesChecked, report.Errors, report.Warnings)
+	if report.Errors > 0 {
+		ui.PrintError(summary)
+	} else if report.Warnings > 0 {
+		ui.PrintWarning(summary)
+	} else {
+		ui.PrintSuccess(summary)
+	}
+}
+
+// skillLinter acumula los problemas de un archivo
+type skillLinter struct {
+	path   string
+	issues []LintIssue
+}
+
+func (l *skillLinter) report(severity string, node *yaml.Node, msg string, args ...interface{}) {
+	// El YAML empieza en la misma línea del primer "---", así que sus líneas coinciden con las del archivo
+	line, col := 1, 1
+	if node != nil {
+		line, col = node.Line, node.Column
+	}
+	l.issues = append(l.issues, LintIssue{
+		Path:     l.path,
+		Line:     line,
+		Column:   col,
+		Severity: severity,
+		Message:  fmt.Sprintf(msg, args...),
+	})
+}
+
+// lintSkillFile valida el frontmatter de una skill contra skillLintSchema
+func lintSkillFile(path string) []LintIssue {
+	l := &skillLinter{path: path}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		l.report(lintError, nil, "no se pudo leer: %v", err)
+		return l.issues
+	}
+
+	if !bytes.HasPrefix(content, []byte("---")) {
+		l.report(lintError, nil, "falta frontmatter (el archivo debe iniciar con ---)")
+		return l.issues
+	}
+	parts := bytes.SplitN(content, []byte("---"), 3)
+	if len(parts) < 3 {
+		l.report(lintError, nil, "frontmatter sin cerrar (falta el segundo ---)")
+		return l.issues
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(parts[1], &doc); err != nil {
+		var lineNode *yaml.Node
+		if m := yamlErrorLineRegex.FindStringSubmatch(err.Error()); len(m) > 1 {
+			// Los errores de yaml.v3 numeran desde 0 a partir del delimitador "---"
+			lineNode = &yaml.Node{Column: 1}
+			fmt.Sscan(m[1], &lineNode.Line)
+			lineNode.Line++
+		}
+		l.report(lintError, lineNode, "YAML inválido: %v", err)
+		return l.issues
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		l.report(lintError, nil, "el frontmatter debe ser un objeto YAML")
+		return l.issues
+	}
+
+	top := doc.Content[0]
+	l.lintMapping(top, skillLintSchema, "")
+
+	values := mappingValues(top)
+	if isScaffoldSkill(values) {
+		return l.issues
+	}
+
+	if n, ok := values["name"]; !ok || strings.TrimSpace(n.Value) == "" {
+		l.report(lintWarning, top, "falta 'name'")
+	}
+	if n, ok := values["description"]; !ok || strings.TrimSpace(n.Value) == "" {
+		l.report(lintError, top, "falta 'description'")
+	}
+	if n, ok := values["agent_rules"]; ok && n.Kind == yaml.SequenceNode && len(n.Content) == 0 {
+		l.report(lintWarning, n, "'agent_rules' está vacío")
+	}
+	if check, ok := values["check"]; ok && check.Kind == yaml.MappingNode {
+		l.lintCheckValues(mappingValues(check))
+	}
+	if _, ok := values["extends"]; !ok {
+		l.lintRemovalsWithoutExtends(values)
+	}
+
+	return l.issues
+}
+
+// lintMapping valida claves desconocidas, tipos y strings vacíos de un objeto
+func (l *skillLinter) lintMapping(node *yaml.Node, schema map[string]lintField, prefix string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+
+		field, ok := schema[key]
+		if !ok {
+			msg := fmt.Sprintf("clave desconocida '%s%s'", prefix, key)
+			if suggestion := closestKey(key, schema); suggestion != "" {
+				msg += fmt.Sprintf(" (¿quisiste decir '%s'?)", suggestion)
+			}
+			l.report(lintError, keyNode, "%s", msg)
+			continue
+		}
+
+		if !l.matchesKind(valueNode, field.Kind) {
+			l.report(lintError, valueNode, "'%s%s' debe ser %s", prefix, key, field.Kind)
+			continue
+		}
+
+		switch field.Kind {
+		case lintString:
+			l.lintAllowedValue(valueNode, field, prefix+key)
+		case lintStringList:
+			for _, item := range valueNode.Content {
+				if strings.HasPrefix(item.Tag, "!") && !strings.HasPrefix(item.Tag, "!!") {
+					l.report(lintError, item, "'%s' se interpreta como tag YAML; usa comillas (\"%svalor\") para quitar un valor heredado", item.Tag, removePrefix)
+					continue
+				}
+				if strings.TrimSpace(item.Value) == "" {
+					l.report(lintError, item, "'%s%s' contiene un valor vacío", prefix, key)
+					continue
+				}
+				l.lintAllowedValue(item, field, prefix+key)
+			}
+		case lintMap:
+			l.lintMapping(valueNode, field.Fields, prefix+key+".")
+		case lintMapList:
+			for _, item := range valueNode.Content {
+				l.lintMapping(item, field.Fields, prefix+key+"[].")
+			}
+		case lintStringMap:
+			for j := 0; j+1 < len(valueNode.Content); j += 2 {
+				l.lintAllowedValue(valueNode.Content[j], field, prefix+key)
+				if strings.TrimSpace(valueNode.Content[j+1].Value) == "" {
+					l.report(lintError, valueNode.Content[j+1], "'%s%s.%s' está vacío", prefix, key, valueNode.Content[j].Value)
+				}
+			}
+		}
+	}
+}
+
+// lintAllowedValue valida un valor contra el enum (error) o los ejemplos estándar (advertencia) del campo
+func (l *skillLinter) lintAllowedValue(node *yaml.Node, field lintField, key string) {
+	if node.Kind != yaml.ScalarNode || node.Value == "" {
+		return
+	}
+	if len(field.Enum) > 0 && !containsString(field.Enum, node.Value) {
+		l.report(lintError, node, "%s '%s' no es válido (%s)", key, node.Value, strings.Join(field.Enum, ", "))
+	}
+	if len(field.Examples) > 0 && !containsString(field.Examples, node.Value) {
+		l.report(lintWarning, node, "%s '%s' no es estándar (%s)", key, node.Value, strings.Join(field.Examples, ", "))
+	}
+}
+
+func (l *skillLinter) matchesKind(node *yaml.Node, kind lintKind) bool {
+	if node.Tag == "!!null" {
+		return true // Clave vacía equivale a valor por defecto
+	}
+	switch kind {
+	case lintString:
+		return node.Kind == yaml.ScalarNode
+	case lintStringList:
+		if node.Kind != yaml.SequenceNode {
+			return false
+		}
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return false
+			}
+		}
+		return true
+	case lintMap:
+		return node.Kind == yaml.MappingNode
+	case lintMapList:
+		if node.Kind != yaml.SequenceNode {
+			return false
+		}
+		for _, item := range node.Content {
+			if item.Kind != yaml.MappingNode {
+				return false
+			}
+		}
+		return true
+	case lintStringMap:
+		if node.Kind != yaml.MappingNode {
+			return false
+		}
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// lintCheckValues valida el contenido de las reglas de check (regex, globs y variables de entorno)
+func (l *skillLinter) lintCheckValues(check map[string]*yaml.Node) {
+	for _, key := range []string{"required_deps", "deps_exist_any", "forbidden_deps"} {
+		n, ok := check[key]
+		if !ok || n.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, item := range n.Content {
+			pattern, ok := depPattern(strings.TrimPrefix(item.Value, removePrefix))
+			if !ok {
+				continue
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				l.report(lintError, item, "regex inválida en check.%s: '%s' (%v)", key, item.Value, err)
+			}
+		}
+	}
+
+	for _, key := range []string{"files_exist", "files_exist_any"} {
+		n, ok := check[key]
+		if !ok || n.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, item := range n.Content {
+			value := strings.TrimPrefix(item.Value, removePrefix)
+			if _, err := filepath.Match(value, ""); err != nil {
+				l.report(lintError, item, "glob inválido en check.%s: '%s'", key, item.Value)
+			}
+			if filepath.IsAbs(value) || strings.HasPrefix(value, "..") {
+				l.report(lintWarning, item, "check.%s debe ser relativo a la raíz del proyecto: '%s'", key, item.Value)
+			}
+		}
+	}
+
+	if n, ok := check["env_vars"]; ok && n.Kind == yaml.SequenceNode {
+		for _, item := range n.Content {
+			if value := strings.TrimPrefix(item.Value, removePrefix); value != "" && !envVarNameRegex.MatchString(value) {
+				l.report(lintError, item, "nombre de variable de entorno inválido: '%s'", item.Value)
+			}
+		}
+	}
+
+	if n, ok := check["fail_message"]; ok && len(check) == 1 {
+		l.report(lintWarning, n, "check.fail_message sin reglas que puedan fallar")
+	}
+}
+
+// lintRemovalsWithoutExtends advierte de entradas "!valor" en una skill que no hereda de nadie:
+// no quitan nada y se usan tal cual
+func (l *skillLinter) lintRemovalsWithoutExtends(values map[string]*yaml.Node) {
+	lists := []*yaml.Node{values["agent_rules"]}
+	if check, ok := values["check"]; ok && check.Kind == yaml.MappingNode {
+		for _, n := range mappingValues(check) {
+			lists = append(lists, n)
+		}
+	}
+
+	for _, n := range lists {
+		if n == nil || n.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, item := range n.Content {
+			if strings.HasPrefix(item.Value, removePrefix) {
+				l.report(lintWarning, item, "'%s' solo quita valores en skills con 'extends'; aquí se usa tal cual", item.Value)
+			}
+		}
+	}
+}
+
+// mappingValues indexa los valores de un objeto YAML por clave
+func mappingValues(node *yaml.Node) map[string]*yaml.Node {
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		values[node.Content[i].Value] = node.Content[i+1]
+	}
+	return values
+}
+
+// isScaffoldSkill detecta las skills de scaffold, que no requieren name ni description
+func isScaffoldSkill(values map[string]*yaml.Node) bool {
+	_, hasStructure := values["structure"]
+	_, hasCreate := values["create_command"]
+	return hasStructure || hasCreate
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// closestKey sugiere la clave válida más parecida (typos como required_dep)
+func closestKey(key string, schema map[string]lintField) string {
+	best := ""
+	bestDist := 4 // Solo sugerir si está razonablemente cerca
+	for candidate := range schema {
+		if d := levenshtein(key, candidate); d < bestDist || (d == bestDist && best != "" && candidate < best) {
+			best, bestDist = candidate, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
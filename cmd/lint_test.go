@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintSkillFile(t *testing.T) {
+	type wantIssue struct {
+		line, column int
+		severity     string
+		contains     string
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []wantIssue
+	}{
+		{
+			name:    "skill válida",
+			content: "---\nname: core\ndescription: Reglas base\napplies_to: [nextjs]\ncheck:\n  required_deps: [\"/^@radix-ui\\\\//\"]\n---\n",
+		},
+		{
+			name:    "clave desconocida con sugerencia",
+			content: "---\nname: core\ndescription: x\ncheck:\n  required_dep: [zod]\n---\n",
+			want:    []wantIssue{{5, 3, lintError, "¿quisiste decir 'required_deps'?"}},
+		},
+		{
+			name:    "tipo incorrecto",
+			content: "---\nname: core\ndescription: x\nagent_rules: usa zod\n---\n",
+			want:    []wantIssue{{4, 14, lintError, "debe ser lista de strings"}},
+		},
+		{
+			name:    "applies_to inválido",
+			content: "---\nname: core\ndescription: x\napplies_to: [nextjs, rails]\n---\n",
+			want:    []wantIssue{{4, 22, lintError, "applies_to 'rails' no es válido"}},
+		},
+		{
+			name:    "falta description",
+			content: "---\nname: core\n---\n",
+			want:    []wantIssue{{2, 1, lintError, "falta 'description'"}},
+		},
+		{
+			name:    "regex inválida",
+			content: "---\nname: core\ndescription: x\ncheck:\n  forbidden_deps:\n    - moment\n    - \"/^(lodash/\"\n---\n",
+			want:    []wantIssue{{7, 7, lintError, "regex inválida en check.forbidden_deps"}},
+		},
+		{
+			name:    "regex inválida en una entrada que quita",
+			content: "---\nname: core\ndescription: x\nextends: [base]\ncheck:\n  deps_exist_any: [\"!/[a-/\"]\n---\n",
+			want:    []wantIssue{{6, 20, lintError, "regex inválida en check.deps_exist_any"}},
+		},
+		{
+			name:    "glob inválido",
+			content: "---\nname: core\ndescription: x\ncheck:\n  files_exist: [\"src/[a.ts\"]\n---\n",
+			want:    []wantIssue{{5, 17, lintError, "glob inválido"}},
+		},
+		{
+			name:    "variable de entorno inválida",
+			content: "---\nname: core\ndescription: x\ncheck:\n  env_vars: [DATABASE-URL]\n---\n",
+			want:    []wantIssue{{5, 14, lintError, "variable de entorno inválido"}},
+		},
+		{
+			name:    "agent_rules vacío",
+			content: "---\nname: core\ndescription: x\nagent_rules: []\n---\n",
+			want:    []wantIssue{{4, 14, lintWarning, "'agent_rules' está vacío"}},
+		},
+		{
+			name:    "sin frontmatter",
+			content: "# Core\n",
+			want:    []wantIssue{{1, 1, lintError, "falta frontmatter"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "core.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			issues := lintSkillFile(path)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("issues = %+v, want %d", issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := issues[i]
+				if got.Line != want.line || got.Column != want.column || got.Severity != want.severity || !strings.Contains(got.Message, want.contains) {
+					t.Errorf("issue = %d:%d %s %q, want %d:%d %s %q", got.Line, got.Column, got.Severity, got.Message, want.line, want.column, want.severity, want.contains)
+				}
+			}
+		})
+	}
+}
+
+func TestHasDependency(t *testing.T) {
+	pkg := &PackageJSON{
+		Dependencies:    map[string]string{"@radix-ui/react-dialog": "1.0.0", "zod": "3.0.0"},
+		DevDependencies: map[string]string{"vitest": "1.0.0"},
+	}
+
+	tests := []struct {
+		dep  string
+		want bool
+	}{
+		{dep: "zod", want: true},
+		{dep: "vitest", want: true},
+		{dep: "moment", want: false},
+		{dep: "/^@radix-ui\\//", want: true},
+		{dep: "/^@prisma\\//", want: false},
+		{dep: "/^(lodash/", want: false}, // Regex inválida: no coincide con nada
+		{dep: "/", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dep, func(t *testing.T) {
+			if got := hasDependency(pkg, tt.dep); got != tt.want {
+				t.Errorf("hasDependency(%q) = %v, want %v", tt.dep, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// splitSkillLanguage separa el sufijo de idioma de una skill: "backend/core.en.md" -> ("backend/core.md", "en")
+func splitSkillLanguage(relPath string) (string, string) {
+	name := strings.TrimSuffix(relPath, ".md")
+	ext := path.Ext(name)
+	if lang := strings.TrimPrefix(ext, "."); ext != "" && containsString(knownLanguages, lang) {
+		return strings.TrimSuffix(name, ext) + ".md", lang
+	}
+	return relPath, ""
+}
+
+// groupSkillTranslations agrupa las variantes por idioma de cada skill.
+// El archivo sin sufijo es la versión principal; si no existe se usa la primera variante
+// según knownLanguages. El mapa `translations` del frontmatter agrega o reemplaza variantes.
+func groupSkillTranslations(source string, files []skillFile) []indexedSkill {
+	var order []string
+	primary := make(map[string]skillFile)
+	variants := make(map[string]map[string]skillFile)
+
+	for _, f := range files {
+		base, lang := splitSkillLanguage(f.RelPath)
+		if _, ok := primary[base]; !ok && variants[base] == nil {
+			order = append(order, base)
+		}
+		if lang == "" {
+			primary[base] = f
+			continue
+		}
+		if variants[base] == nil {
+			variants[base] = make(map[string]skillFile)
+		}
+		variants[base][lang] = f
+	}
+
+	var skills []indexedSkill
+	targets := make(map[string]bool)
+	for _, base := range order {
+		main, ok := primary[base]
+		if !ok {
+			for _, lang := range knownLanguages {
+				if v, found := variants[base][lang]; found {
+					main = v
+					break
+				}
+			}
+		}
+
+		item := newIndexedSkill(source, base, main)
+		translations := make(map[string]string)
+		for lang, v := range variants[base] {
+			translations[lang] = v.Path
+		}
+		for lang, rel := range item.FM.Translations {
+			target := filepath.Join(filepath.Dir(main.Path), filepath.FromSlash(rel))
+			translations[lang] = target
+			targets[target] = true
+		}
+		if len(translations) > 0 {
+			item.Info.Translations = translations
+		}
+		skills = append(skills, item)
+	}
+
+	// Un archivo que otra skill declara como traducción no es una skill aparte
+	result := skills[:0]
+	for _, s := range skills {
+		if !targets[s.Info.Path] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// localizeSkill devuelve la skill apuntando a su variante en el idioma pedido.
+// Si no hay traducción a ese idioma se queda con la versión principal (found = false).
+func localizeSkill(skill SkillInfo, lang string) (localized SkillInfo, found bool) {
+	if p, ok := skill.Translations[lang]; ok {
+		skill.Path = p
+		return skill, true
+	}
+	return skill, false
+}
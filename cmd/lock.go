@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+)
+
+const (
+	skillsLockFile    = "skills.lock"
+	skillsLockVersion = 1
+	localSkillsSource = "local"
+)
+
+// SkillsLock registra la procedencia de cada skill vendorizada en .kolyn/skills/
+type SkillsLock struct {
+	Version int           `json:"version"`
+	Skills  []LockedSkill `json:"skills"`
+}
+
+// LockedSkill describe de dónde salió una skill vendorizada y con qué contenido
+type LockedSkill struct {
+	ID           string `json:"id"` // Identificador canónico (source/category/name)
+	Name         string `json:"name"`
+	LocalPath    string `json:"local_path"`              // Ruta relativa al proyecto (ej. ./.kolyn/skills/core.md)
+	Source       string `json:"source"`                  // URL del repositorio o "local" (~/.kolyn/skills)
+	Commit       string `json:"commit,omitempty"`        // Commit del source al momento de vendorizar
+	Path         string `json:"path"`                    // Ruta relativa dentro del source
+	Hash         string `json:"hash"`                    // sha256 del archivo en el source
+	RenderedHash string `json:"rendered_hash,omitempty"` // sha256 de la copia cuando se renderizaron sus variables
+}
+
+// localHash es el hash esperado del archivo vendorizado (la copia renderizada si la hay)
+func (l LockedSkill) localHash() string {
+	if l.RenderedHash != "" {
+		return l.RenderedHash
+	}
+	return l.Hash
+}
+
+func getSkillsLockPath(root string) string {
+	return filepath.Join(root, ".kolyn", skillsLockFile)
+}
+
+// loadSkillsLock lee .kolyn/skills.lock; si no existe devuelve un lock vacío
+func loadSkillsLock(root string) (*SkillsLock, error) {
+	lock := &SkillsLock{Version: skillsLockVersion}
+
+	data, err := os.ReadFile(getSkillsLockPath(root))
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("error parseando %s: %w", skillsLockFile, err)
+	}
+	return lock, nil
+}
+
+func saveSkillsLock(root string, lock *SkillsLock) error {
+	sort.Slice(lock.Skills, func(i, j int) bool {
+		return lock.Skills[i].LocalPath < lock.Skills[j].LocalPath
+	})
+	lock.Version = skillsLockVersion
+
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := getSkillsLockPath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// find busca una entrada por su ruta local
+func (l *SkillsLock) find(localPath string) *LockedSkill {
+	for i := range l.Skills {
+		if l.Skills[i].LocalPath == localPath {
+			return &l.Skills[i]
+		}
+	}
+	return nil
+}
+
+// upsert agrega o reemplaza la entrada con la misma ruta local
+func (l *SkillsLock) upsert(entry LockedSkill) {
+	if existing := l.find(entry.LocalPath); existing != nil {
+		*existing = entry
+		return
+	}
+	l.Skills = append(l.Skills, entry)
+}
+
+// remove elimina la entrada con la ruta local indicada
+func (l *SkillsLock) remove(localPath string) {
+	kept := l.Skills[:0]
+	for _, s := range l.Skills {
+		if s.LocalPath != localPath {
+			kept = append(kept, s)
+		}
+	}
+	l.Skills = kept
+}
+
+// prune descarta entradas cuyo archivo vendorizado ya no existe
+func (l *SkillsLock) prune(root string) {
+	kept := l.Skills[:0]
+	for _, s := range l.Skills {
+		if exists(filepath.Join(root, filepath.FromSlash(s.LocalPath))) {
+			kept = append(kept, s)
+		}
+	}
+	l.Skills = kept
+}
+
+// lockedSkillID devuelve el ID de una entrada; los locks previos a los IDs lo derivan del source y la ruta
+func lockedSkillID(l LockedSkill) string {
+	if l.ID != "" {
+		return l.ID
+	}
+	source := l.Source
+	if source != localSkillsSource {
+		source = config.SanitizeRepoName(source)
+	}
+	return skillID(source, l.Path)
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func hashFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return hashContent(content), nil
+}
+
+// toLocalSkillPath convierte una ruta absoluta dentro del proyecto al formato ./relativo usado en Agent.md y el lock
+func toLocalSkillPath(root, path string) string {
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		relPath = path
+	}
+	relPath = filepath.ToSlash(relPath)
+	if !strings.HasPrefix(relPath, "./") && !strings.HasPrefix(relPath, "../") {
+		relPath = "./" + relPath
+	}
+	return relPath
+}
+
+// newLockedSkill construye la entrada del lock para una skill recién vendorizada
+func newLockedSkill(ctx context.Context, skill SkillInfo, localPath string) (LockedSkill, error) {
+	hash, err := hashFile(skill.Path)
+	if err != nil {
+		return LockedSkill{}, err
+	}
+
+	source, commit, relPath := resolveSkillOrigin(ctx, skill)
+
+	return LockedSkill{
+		ID:        skill.ID,
+		Name:      skill.Name,
+		LocalPath: localPath,
+		Source:    source,
+		Commit:    commit,
+		Path:      relPath,
+		Hash:      hash,
+	}, nil
+}
+
+// resolveSkillOrigin identifica el source (URL o "local"), el commit y la ruta relativa de una skill
+// a partir del directorio y el source con los que se indexó
+func resolveSkillOrigin(ctx context.Context, skill SkillInfo) (source, commit, relPath string) {
+	relPath = skill.RelPath
+	if skill.Dir != "" {
+		// Con una variante por idioma el archivo real no es RelPath (core.en.md en vez de core.md)
+		if rel, err := filepath.Rel(skill.Dir, skill.Path); err == nil {
+			relPath = filepath.ToSlash(rel)
+		}
+	}
+	if relPath == "" {
+		relPath = filepath.Base(skill.Path)
+	}
+
+	if skill.Source == localSkillsSource || skill.Dir == "" {
+		return localSkillsSource, "", relPath
+	}
+
+	source, err := gitOutput(ctx, skill.Dir, "remote", "get-url", "origin")
+	if err != nil || source == "" {
+		source = skill.Source
+	}
+	commit, _ = gitOutput(ctx, skill.Dir, "rev-parse", "HEAD")
+	return source, commit, relPath
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// profilesDir es la carpeta de perfiles en la raíz de cada repo de skills (y de ~/.kolyn/skills)
+const profilesDir = "profiles"
+
+var skillsProfilesJSON bool
+
+var skillsProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Lista los perfiles de skills disponibles para kolyn init --profile",
+	Long: `Un perfil (profiles/<nombre>.yml en un repo de skills) define un conjunto de skills
+por ID y/o por capability para aplicarlo en un paso con 'kolyn init --profile <nombre>'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSkillsProfiles(skillsProfilesJSON)
+	},
+}
+
+func init() {
+	skillsProfilesCmd.Flags().BoolVar(&skillsProfilesJSON, "json", false, "Imprime los perfiles en JSON")
+	skillsCmd.AddCommand(skillsProfilesCmd)
+}
+
+// SkillProfile es un preset de skills definido en profiles/<nombre>.yml
+type SkillProfile struct {
+	Name         string   `yaml:"name" json:"name"`
+	Description  string   `yaml:"description" json:"description,omitempty"`
+	Skills       []string `yaml:"skills" json:"skills,omitempty"`             // IDs canónicos, o relativos al source del perfil
+	Capabilities []string `yaml:"capabilities" json:"capabilities,omitempty"` // Todas las skills del source con esas capabilities
+	Source       string   `yaml:"-" json:"source"`
+	Path         string   `yaml:"-" json:"path"`
+}
+
+// loadProfiles lee los perfiles de ~/.kolyn/skills y de todos los sources
+func loadProfiles() ([]SkillProfile, error) {
+	dirs, err := getSkillsDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []SkillProfile
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(filepath.Join(dir.Path, profilesDir))
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+				continue
+			}
+
+			path := filepath.Join(dir.Path, profilesDir, entry.Name())
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+
+			var p SkillProfile
+			if err := yaml.Unmarshal(data, &p); err != nil {
+				ui.PrintWarning("Perfil inválido %s: %v", path, err)
+				continue
+			}
+			if p.Name == "" {
+				p.Name = strings.TrimSuffix(entry.Name(), ext)
+			}
+			p.Source = dir.Source
+			p.Path = path
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+// findProfile busca un perfil por nombre o por source/nombre
+func findProfile(name string) (*SkillProfile, error) {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []SkillProfile
+	for _, p := range profiles {
+		if p.Name == name || p.Source+"/"+p.Name == name {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no se encontró el perfil '%s' (usa 'kolyn skills profiles' para ver los disponibles)", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		var names []string
+		for _, m := range matches {
+			names = append(names, m.Source+"/"+m.Name)
+		}
+		return nil, fmt.Errorf("el perfil '%s' existe en varios sources, usa: %s", name, strings.Join(names, ", "))
+	}
+}
+
+// resolve devuelve las skills del perfil: las listadas por ID y las del mismo source cuya
+// capability está en el perfil y aplican al tipo de proyecto. También los IDs que no existen.
+func (p SkillProfile) resolve(skills []SkillInfo, projectType string) ([]SkillInfo, []string) {
+	byID := make(map[string]SkillInfo, len(skills))
+	for _, s := range skills {
+		if _, dup := byID[s.ID]; !dup {
+			byID[s.ID] = s
+		}
+	}
+
+	var selected []SkillInfo
+	included := make(map[string]bool)
+	add := func(s SkillInfo) {
+		if !included[s.ID] {
+			included[s.ID] = true
+			selected = append(selected, s)
+		}
+	}
+
+	var missing []string
+	for _, id := range p.Skills {
+		if s, ok := byID[id]; ok {
+			add(s)
+		} else if s, ok := byID[p.Source+"/"+id]; ok {
+			add(s)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(p.Capabilities) > 0 {
+		filter := skillFilter{Source: p.Source, ProjectType: projectType}
+		for _, s := range skills {
+			if filter.matches(s) && containsString(p.Capabilities, s.Capability) {
+				add(s)
+			}
+		}
+	}
+	return selected, missing
+}
+
+// runSkillsProfiles lista los perfiles disponibles
+func runSkillsProfiles(asJSON bool) error {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].Source+"/"+profiles[i].Name < profiles[j].Source+"/"+profiles[j].Name
+	})
+
+	if asJSON {
+		if profiles == nil {
+			profiles = []SkillProfile{}
+		}
+		data, err := json.MarshalIndent(profiles, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error generando JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(profiles) == 0 {
+		ui.PrintWarning("No hay perfiles. Crea %s/<nombre>.yml en tu repo de skills.", profilesDir)
+		return nil
+	}
+
+	ui.ShowSection("🧩 Perfiles de Skills")
+	for _, p := range profiles {
+		ui.WhiteText.Printf("  %s", p.Name)
+		ui.Gray.Printf("  (%s)\n", p.Source)
+		if p.Description != "" {
+			ui.Gray.Printf("     %s\n", p.Description)
+		}
+		var parts []string
+		if len(p.Skills) > 0 {
+			parts = append(parts, fmt.Sprintf("%d skills", len(p.Skills)))
+		}
+		if len(p.Capabilities) > 0 {
+			parts = append(parts, "capabilities: "+strings.Join(p.Capabilities, ", "))
+		}
+		if len(parts) > 0 {
+			ui.CyanText.Printf("     › %s\n", strings.Join(parts, " · "))
+		}
+	}
+	fmt.Println()
+	ui.Gray.Println("Aplica uno con 'kolyn init --profile <nombre>' (o --select para revisarlo en el selector).")
+	return nil
+}
+
+// applyProfile resuelve el perfil de kolyn init y avisa de los IDs que no existen
+func applyProfile(name string, skills []SkillInfo, projectType string) ([]SkillInfo, error) {
+	profile, err := findProfile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, missing := profile.resolve(skills, projectType)
+	for _, id := range missing {
+		ui.PrintWarning("El perfil '%s' referencia una skill que no existe: %s", profile.Name, id)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("el perfil '%s' no seleccionó ninguna skill para un proyecto %s", profile.Name, projectType)
+	}
+
+	ui.PrintInfo("Perfil '%s' (%s): %d skills", profile.Name, profile.Source, len(selected))
+	return selected, nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+)
+
+func TestSkillProfileResolve(t *testing.T) {
+	skills := []SkillInfo{
+		{ID: "team/frontend/react", Source: "team", Capability: "ui", AppliesTo: []string{"nextjs"}},
+		{ID: "team/database/drizzle", Source: "team", Capability: "database", AppliesTo: []string{"nextjs", "node"}},
+		{ID: "team/database/gorm", Source: "team", Capability: "database", AppliesTo: []string{"go"}},
+		{ID: "team/core/git", Source: "team", Capability: "core"},
+		{ID: "otro/database/prisma", Source: "otro", Capability: "database"},
+		{ID: "shared/auth", Source: "otro", Capability: "auth"},
+	}
+
+	tests := []struct {
+		name        string
+		profile     SkillProfile
+		projectType string
+		wantIDs     []string
+		wantMissing []string
+	}{
+		{
+			name:    "IDs canónicos y relativos al source",
+			profile: SkillProfile{Source: "team", Skills: []string{"team/frontend/react", "core/git", "shared/auth"}},
+			wantIDs: []string{"team/frontend/react", "team/core/git", "shared/auth"},
+		},
+		{
+			name:        "IDs que no existen",
+			profile:     SkillProfile{Source: "team", Skills: []string{"frontend/vue", "team/core/git"}},
+			wantIDs:     []string{"team/core/git"},
+			wantMissing: []string{"frontend/vue"},
+		},
+		{
+			name:        "capabilities solo del mismo source y tipo de proyecto",
+			profile:     SkillProfile{Source: "team", Capabilities: []string{"database", "core"}},
+			projectType: "nextjs",
+			wantIDs:     []string{"team/database/drizzle", "team/core/git"},
+		},
+		{
+			name:        "capability e ID de la misma skill no se duplican",
+			profile:     SkillProfile{Source: "team", Skills: []string{"database/gorm"}, Capabilities: []string{"database"}},
+			projectType: "go",
+			wantIDs:     []string{"team/database/gorm"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, missing := tt.profile.resolve(skills, tt.projectType)
+			var ids []string
+			for _, s := range selected {
+				ids = append(ids, s.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ids = %q, want %q", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("missing = %q, want %q", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestFindProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(config.EnvKolynHome, home)
+	t.Chdir(t.TempDir())
+
+	cfg := &config.GlobalConfig{Language: "es"}
+	profiles := map[string]map[string]string{
+		"a": {"web.yml": "description: Web de a\n", "api.yaml": "name: backend\n"},
+		"b": {"web.yml": "description: Web de b\n", "notas.txt": "no es un perfil\n"},
+	}
+	for source, files := range profiles {
+		dir := filepath.Join(home, "sources", source, profilesDir)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cfg.SkillsSources = append(cfg.SkillsSources, config.SkillSource{Name: source, URL: "git@example.com:" + source + ".git"})
+	}
+	if err := config.SaveGlobalConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query      string
+		wantSource string
+		wantDesc   string
+		wantErr    bool
+	}{
+		{query: "a/web", wantSource: "a", wantDesc: "Web de a"},
+		{query: "b/web", wantSource: "b", wantDesc: "Web de b"},
+		{query: "backend", wantSource: "a"}, // El name del YAML reemplaza al nombre del archivo
+		{query: "api", wantErr: true},
+		{query: "web", wantErr: true}, // Existe en los dos sources
+		{query: "notas", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			p, err := findProfile(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if p.Source != tt.wantSource || p.Description != tt.wantDesc {
+				t.Errorf("got %s (%q), want %s (%q)", p.Source, p.Description, tt.wantSource, tt.wantDesc)
+			}
+		})
+	}
+}
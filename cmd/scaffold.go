@@ -1,17 +1,17 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var scaffoldCmd = &cobra.Command{
@@ -23,23 +23,6 @@ var scaffoldCmd = &cobra.Command{
 	},
 }
 
-type ScaffoldFile struct {
-	Path    string `yaml:"path"`
-	Content string `yaml:"content"`
-}
-
-type ScaffoldStructure struct {
-	Directories []string       `yaml:"directories"`
-	Files       []ScaffoldFile `yaml:"files"`
-}
-
-type ScaffoldSkill struct {
-	Type          string            `yaml:"type"`
-	Framework     string            `yaml:"framework"`
-	CreateCommand string            `yaml:"create_command"`
-	Structure     ScaffoldStructure `yaml:"structure"`
-}
-
 func runScaffold(ctx context.Context) error {
 	ui.ShowSection("🏗️  Kolyn Scaffold")
 
@@ -57,8 +40,11 @@ func runScaffold(ctx context.Context) error {
 	switch choice {
 	case "1":
 		// Locate the skill file
-		home, _ := os.UserHomeDir()
-		skillPath = filepath.Join(home, ".kolyn", "sources", "github.com-isai-arellano-kolyn-skills", "scaffold", "web", "nextjs.md")
+		paths, err := config.GetPaths()
+		if err != nil {
+			return err
+		}
+		skillPath = filepath.Join(paths.SourcesDir(), "github.com-isai-arellano-kolyn-skills", "scaffold", "web", "nextjs.md")
 
 		// Fallback to old path if not found in sources
 		if _, err := os.Stat(skillPath); os.IsNotExist(err) {
@@ -70,7 +56,7 @@ func runScaffold(ctx context.Context) error {
 				skillPath = localPath
 			} else {
 				// Try ~/.kolyn/skills old fallback
-				skillPath = filepath.Join(home, ".kolyn", "skills", "scaffold", "web", "nextjs.md")
+				skillPath = filepath.Join(paths.SkillsDir(), "scaffold", "web", "nextjs.md")
 				if _, err := os.Stat(skillPath); os.IsNotExist(err) {
 					return fmt.Errorf("no se encontró la definición de scaffold para Next.js. Ejecuta 'kolyn sync' primero.")
 				}
@@ -111,30 +97,18 @@ func runScaffold(ctx context.Context) error {
 	}
 }
 
-func loadScaffoldSkill(path string) (*ScaffoldSkill, error) {
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	if !bytes.HasPrefix(content, []byte("---")) {
+func loadScaffoldSkill(path string) (*SkillFrontmatter, error) {
+	skill, err := parseSkillFrontmatter(path)
+	if errors.Is(err, errNoFrontmatter) {
 		return nil, fmt.Errorf("formato inválido: falta frontmatter")
 	}
-
-	parts := bytes.SplitN(content, []byte("---"), 3)
-	if len(parts) < 3 {
-		return nil, fmt.Errorf("formato inválido: frontmatter mal formado")
-	}
-
-	var skill ScaffoldSkill
-	if err := yaml.Unmarshal(parts[1], &skill); err != nil {
+	if err != nil {
 		return nil, err
 	}
-
-	return &skill, nil
+	return skill, nil
 }
 
-func createNewProject(ctx context.Context, scaffold *ScaffoldSkill) error {
+func createNewProject(ctx context.Context, scaffold *SkillFrontmatter) error {
 	ui.PrintQuestion("Nombre del proyecto:")
 	name := ui.ReadInput("> ")
 	if name == "" {
@@ -177,12 +151,12 @@ func createNewProject(ctx context.Context, scaffold *ScaffoldSkill) error {
 	// OR we run non-interactive with defaults.
 	// Let's ask the user if they want to configure it now
 	if ui.AskYesNo("¿Deseas configurar las capabilities del proyecto ahora (DB, Auth, etc)?") {
-		if err := RunInitProject(ctx, absPath, true); err != nil {
+		if err := RunInitProject(ctx, absPath, InitOptions{Interactive: true}); err != nil {
 			ui.PrintWarning("No se pudo completar la inicialización: %v", err)
 		}
 	} else {
 		// Run non-interactive with defaults
-		RunInitProject(ctx, absPath, false)
+		RunInitProject(ctx, absPath, InitOptions{})
 	}
 
 	ui.Gray.Printf("\n  cd %s\n", name)
@@ -191,7 +165,7 @@ func createNewProject(ctx context.Context, scaffold *ScaffoldSkill) error {
 	return nil
 }
 
-func auditExistingProject(ctx context.Context, scaffold *ScaffoldSkill) error {
+func auditExistingProject(ctx context.Context, scaffold *SkillFrontmatter) error {
 	cwd, _ := os.Getwd()
 	ui.PrintStep("Analizando estructura en %s...", cwd)
 
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillsSearchJSON  bool
+	skillsSearchLimit int
+)
+
+var skillsSearchCmd = &cobra.Command{
+	Use:   "search [query...]",
+	Short: "Busca skills por texto y por campos del frontmatter",
+	Long: `Busca en el frontmatter y el contenido Markdown de ~/.kolyn/skills y todos los sources.
+
+Las palabras se buscan en ID, nombre, descripción, reglas y contenido (todas deben aparecer).
+Los filtros campo:valor limitan los resultados:
+  capability, applies_to, category, source, name, id
+
+Ejemplo:
+  kolyn skills search capability:database applies_to:nextjs drizzle`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSkillsSearch(cmd.Context(), args, skillsSearchJSON, skillsSearchLimit)
+	},
+}
+
+func init() {
+	skillsSearchCmd.Flags().BoolVar(&skillsSearchJSON, "json", false, "Imprime los resultados en JSON")
+	skillsSearchCmd.Flags().IntVarP(&skillsSearchLimit, "limit", "n", 20, "Número máximo de resultados (0 = sin límite)")
+	skillsCmd.AddCommand(skillsSearchCmd)
+}
+
+// searchFilterFields son los campos aceptados en filtros campo:valor
+var searchFilterFields = []string{"capability", "applies_to", "category", "source", "name", "id"}
+
+// SkillSearchResult es una skill encontrada con su puntuación
+type SkillSearchResult struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	Category    string   `json:"category"`
+	Path        string   `json:"path"`
+	Description string   `json:"description,omitempty"`
+	Capability  string   `json:"capability,omitempty"`
+	AppliesTo   []string `json:"applies_to,omitempty"`
+	Score       int      `json:"score"`
+	Snippet     string   `json:"snippet,omitempty"`
+}
+
+// skillSearchQuery es la consulta ya separada en palabras y filtros
+type skillSearchQuery struct {
+	Keywords []string
+	Filters  map[string][]string
+}
+
+func parseSearchQuery(args []string) (skillSearchQuery, error) {
+	q := skillSearchQuery{Filters: make(map[string][]string)}
+
+	for _, arg := range args {
+		for _, term := range strings.Fields(arg) {
+			field, value, isFilter := strings.Cut(term, ":")
+			if isFilter && containsString(searchFilterFields, strings.ToLower(field)) {
+				if value == "" {
+					return q, fmt.Errorf("filtro vacío: %s", term)
+				}
+				field = strings.ToLower(field)
+				q.Filters[field] = append(q.Filters[field], strings.ToLower(value))
+				continue
+			}
+			q.Keywords = append(q.Keywords, strings.ToLower(term))
+		}
+	}
+	return q, nil
+}
+
+// indexSkills toma el índice de skills (frontmatter cacheado) y carga el cuerpo Markdown para buscar
+func indexSkills(ctx context.Context) ([]indexedSkill, error) {
+	skills, err := scanSkillIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := skills[:0]
+	for _, s := range skills {
+		content, err := os.ReadFile(s.Info.Path)
+		if err != nil {
+			continue
+		}
+
+		s.Body = string(content)
+		if _, body, err := splitFrontmatter(content); err == nil {
+			s.Body = string(body)
+		}
+		index = append(index, s)
+	}
+	return index, nil
+}
+
+// matchesFilters aplica los filtros campo:valor (todos deben cumplirse; valores del mismo campo se combinan con OR)
+func (s indexedSkill) matchesFilters(filters map[string][]string) bool {
+	for field, values := range filters {
+		var candidates []string
+		switch field {
+		case "capability":
+			candidates = []string{s.FM.Capability}
+		case "applies_to":
+			candidates = s.FM.AppliesTo
+		case "category":
+			candidates = []string{s.Info.Category}
+		case "source":
+			candidates = []string{s.Info.Source}
+		case "name":
+			candidates = []string{s.Info.Name, s.FM.Name}
+		case "id":
+			candidates = []string{s.Info.ID}
+		}
+
+		matched := false
+		for _, want := range values {
+			for _, have := range candidates {
+				have = strings.ToLower(have)
+				if have == want || (field == "category" && strings.HasPrefix(have, want+"/")) ||
+					((field == "id" || field == "name") && strings.Contains(have, want)) {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// score puntúa una skill para las palabras de la consulta; 0 si alguna palabra no aparece
+func (s indexedSkill) score(keywords []string) (int, string) {
+	total := 0
+	snippet := ""
+	body := strings.ToLower(s.Body)
+	rules := strings.ToLower(strings.Join(s.FM.AgentRules, "\n"))
+
+	for _, kw := range keywords {
+		kwScore := 0
+
+		name := strings.ToLower(s.Info.Name)
+		switch {
+		case name == kw || strings.ToLower(s.FM.Name) == kw:
+			kwScore += 10
+		case strings.Contains(name, kw) || strings.Contains(strings.ToLower(s.FM.Name), kw):
+			kwScore += 6
+		}
+		if strings.Contains(strings.ToLower(s.Info.ID), kw) {
+			kwScore += 3
+		}
+		if strings.Contains(strings.ToLower(s.Info.Description), kw) {
+			kwScore += 4
+		}
+		if strings.Contains(strings.ToLower(s.FM.Capability), kw) || containsStringFold(s.FM.AppliesTo, kw) {
+			kwScore += 3
+		}
+		if strings.Contains(rules, kw) {
+			kwScore += 3
+		}
+		if n := strings.Count(body, kw); n > 0 {
+			kwScore += min(n, 5)
+			if snippet == "" {
+				snippet = findSnippet(s.Body, kw)
+			}
+		}
+
+		if kwScore == 0 {
+			return 0, ""
+		}
+		total += kwScore
+	}
+	return total, snippet
+}
+
+// containsStringFold es containsString sin distinguir mayúsculas (applies_to: [NextJS])
+func containsStringFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// findSnippet devuelve la primera línea del cuerpo que contiene la palabra
+func findSnippet(body, keyword string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.Contains(strings.ToLower(line), keyword) {
+			line = strings.TrimSpace(line)
+			if len([]rune(line)) > 100 {
+				line = string([]rune(line)[:100]) + "…"
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// searchSkills ejecuta la consulta sobre el índice y ordena por relevancia
+func searchSkills(index []indexedSkill, q skillSearchQuery) []SkillSearchResult {
+	var results []SkillSearchResult
+	for _, s := range index {
+		if !s.matchesFilters(q.Filters) {
+			continue
+		}
+
+		score, snippet := 1, ""
+		if len(q.Keywords) > 0 {
+			score, snippet = s.score(q.Keywords)
+			if score == 0 {
+				continue
+			}
+		}
+
+		results = append(results, SkillSearchResult{
+			ID:          s.Info.ID,
+			Name:        s.Info.Name,
+			Source:      s.Info.Source,
+			Category:    s.Info.Category,
+			Path:        s.Info.Path,
+			Description: s.Info.Description,
+			Capability:  s.FM.Capability,
+			AppliesTo:   s.FM.AppliesTo,
+			Score:       score,
+			Snippet:     snippet,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// runSkillsSearch busca skills e imprime los resultados como lista o JSON
+func runSkillsSearch(ctx context.Context, args []string, asJSON bool, limit int) error {
+	q, err := parseSearchQuery(args)
+	if err != nil {
+		return err
+	}
+
+	index, err := indexSkills(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := searchSkills(index, q)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	if asJSON {
+		if results == nil {
+			results = []SkillSearchResult{}
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error generando JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(results) == 0 {
+		ui.PrintWarning("No se encontraron skills para: %s", strings.Join(args, " "))
+		return nil
+	}
+
+	ui.ShowSection(fmt.Sprintf("🔎 %d resultados", len(results)))
+	for _, r := range results {
+		ui.WhiteText.Printf("  %s", r.ID)
+		ui.Gray.Printf("  (%d)\n", r.Score)
+		if r.Description != "" {
+			ui.Gray.Printf("     %s\n", r.Description)
+		}
+		if r.Snippet != "" {
+			ui.CyanText.Printf("     › %s\n", r.Snippet)
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testSearchIndex() []indexedSkill {
+	return []indexedSkill{
+		{
+			Info: SkillInfo{ID: "team/database/prisma", Name: "prisma", Source: "team", Category: "database"},
+			FM:   SkillFrontmatter{Name: "Prisma", Capability: "database", AppliesTo: []string{"node"}},
+			Body: "Prisma y Postgres\n",
+		},
+		{
+			Info: SkillInfo{ID: "team/database/drizzle", Name: "drizzle", Source: "team", Category: "database", Description: "Reglas de Drizzle ORM"},
+			FM: SkillFrontmatter{
+				Name:       "Drizzle ORM",
+				Capability: "database",
+				AppliesTo:  []string{"NextJS", "node"},
+				AgentRules: []string{"Usa migraciones de drizzle-kit"},
+			},
+			Body: "# Drizzle\nUsa drizzle con Postgres.\n",
+		},
+	}
+}
+
+func TestIndexedSkillScore(t *testing.T) {
+	skill := testSearchIndex()[1]
+
+	tests := []struct {
+		name        string
+		keywords    []string
+		wantScore   int
+		wantSnippet string
+	}{
+		{name: "nombre exacto, id, descripción, reglas y cuerpo", keywords: []string{"drizzle"}, wantScore: 22, wantSnippet: "# Drizzle"},
+		{name: "nombre parcial y descripción", keywords: []string{"orm"}, wantScore: 10},
+		{name: "applies_to sin distinguir mayúsculas", keywords: []string{"nextjs"}, wantScore: 3},
+		{name: "capability", keywords: []string{"database"}, wantScore: 6},
+		{name: "solo en el cuerpo", keywords: []string{"postgres"}, wantScore: 1, wantSnippet: "Usa drizzle con Postgres."},
+		{name: "todas las palabras suman", keywords: []string{"orm", "nextjs"}, wantScore: 13},
+		{name: "una palabra sin coincidencia descarta la skill", keywords: []string{"drizzle", "mongo"}, wantScore: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, snippet := skill.score(tt.keywords)
+			if score != tt.wantScore {
+				t.Errorf("score = %d, want %d", score, tt.wantScore)
+			}
+			if snippet != tt.wantSnippet {
+				t.Errorf("snippet = %q, want %q", snippet, tt.wantSnippet)
+			}
+		})
+	}
+}
+
+func TestSearchSkills(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantIDs []string
+	}{
+		{name: "empate se ordena por ID", args: []string{"postgres"}, wantIDs: []string{"team/database/drizzle", "team/database/prisma"}},
+		{name: "mayor puntuación primero", args: []string{"prisma postgres"}, wantIDs: []string{"team/database/prisma"}},
+		{name: "filtro applies_to sin distinguir mayúsculas", args: []string{"applies_to:NEXTJS"}, wantIDs: []string{"team/database/drizzle"}},
+		{name: "filtros con OR en el mismo campo", args: []string{"name:prisma", "name:drizzle"}, wantIDs: []string{"team/database/drizzle", "team/database/prisma"}},
+		{name: "filtro y palabra", args: []string{"category:database", "prisma"}, wantIDs: []string{"team/database/prisma"}},
+		{name: "sin resultados", args: []string{"source:otro"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := parseSearchQuery(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, r := range searchSkills(testSearchIndex(), q) {
+				ids = append(ids, r.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ids = %q, want %q", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    skillSearchQuery
+		wantErr bool
+	}{
+		{
+			name: "palabras y filtros",
+			args: []string{"Capability:Database", "Drizzle ORM"},
+			want: skillSearchQuery{Keywords: []string{"drizzle", "orm"}, Filters: map[string][]string{"capability": {"database"}}},
+		},
+		{
+			name: "campo desconocido es una palabra",
+			args: []string{"http://x"},
+			want: skillSearchQuery{Keywords: []string{"http://x"}, Filters: map[string][]string{}},
+		},
+		{name: "filtro vacío", args: []string{"id:"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchQuery(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,14 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var skillsCmd = &cobra.Command{
@@ -28,40 +29,99 @@ var skillsPathsCmd = &cobra.Command{
 	},
 }
 
+var (
+	skillsListFormat      string
+	skillsListJSON        bool
+	skillsListInteractive bool
+	skillsListFilter      skillFilter
+)
+
 var skillsListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "Lista skills y permite ver/editar su contenido",
+	Short: "Lista skills (tabla, JSON o rutas) con filtros",
+	Long: `Lista las skills de ~/.kolyn/skills y todos los sources sin pedir entrada,
+para usarse en scripts y agentes. Con --interactive abre el menú para ver/editar.
+
+Ejemplos:
+  kolyn skills list --capability database --type nextjs
+  kolyn skills list --source local --format paths
+  kolyn skills list --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if skillsListInteractive {
+			return runSkillsList(cmd.Context())
+		}
+		format := skillsListFormat
+		if skillsListJSON {
+			format = "json"
+		}
+		return runSkillsListOutput(cmd.Context(), format, skillsListFilter)
+	},
+}
+
+var (
+	skillsShowFrontmatter bool
+	skillsShowJSON        bool
+)
+
+var skillsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Imprime el contenido o el frontmatter de una skill",
+	Long: `Busca la skill por ID canónico (o por nombre si no es ambiguo) e imprime su contenido.
+Con --frontmatter imprime solo el YAML; con --json imprime la información y el frontmatter en JSON.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runSkillsList(cmd.Context())
+		return runSkillsShow(cmd.Context(), args[0], skillsShowFrontmatter, skillsShowJSON)
 	},
 }
 
-var skillsNewCmd = &cobra.Command{
-	Use:   "new [name]",
-	Short: "Crea una nueva skill usando la plantilla estándar",
-	Long:  `Crea un archivo markdown con la estructura correcta (Frontmatter + Secciones) en ~/.kolyn/skills/`,
-	Args:  cobra.MaximumNArgs(1),
+var skillsRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Elimina una skill vendorizada del proyecto y regenera Agent.md",
+	Long:  `Borra la skill de .kolyn/skills/ del proyecto actual y actualiza Agent.md sin ella.`,
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name := ""
-		if len(args) > 0 {
-			name = args[0]
+		root, err := currentProjectRoot()
+		if err != nil {
+			return err
 		}
-		return runSkillsNew(cmd.Context(), name)
+		return runSkillsRemove(root, args[0])
 	},
 }
 
 func init() {
+	skillsListCmd.Flags().StringVar(&skillsListFormat, "format", "table", "Formato de salida: table, json o paths")
+	skillsListCmd.Flags().BoolVar(&skillsListJSON, "json", false, "Atajo de --format json")
+	skillsListCmd.Flags().BoolVarP(&skillsListInteractive, "interactive", "i", false, "Abre el menú interactivo para ver/editar skills")
+	skillsListCmd.Flags().StringVar(&skillsListFilter.Source, "source", "", "Filtra por source (local o carpeta en ~/.kolyn/sources)")
+	skillsListCmd.Flags().StringVar(&skillsListFilter.Category, "category", "", "Filtra por categoría (incluye subcategorías)")
+	skillsListCmd.Flags().StringVar(&skillsListFilter.Capability, "capability", "", "Filtra por capability del frontmatter")
+	skillsListCmd.Flags().StringVar(&skillsListFilter.ProjectType, "type", "", "Filtra por tipo de proyecto (applies_to)")
+
+	skillsShowCmd.Flags().BoolVar(&skillsShowFrontmatter, "frontmatter", false, "Imprime solo el frontmatter YAML")
+	skillsShowCmd.Flags().BoolVar(&skillsShowJSON, "json", false, "Imprime la información y el frontmatter en JSON")
+
 	skillsCmd.AddCommand(skillsPathsCmd)
 	skillsCmd.AddCommand(skillsListCmd)
-	skillsCmd.AddCommand(skillsNewCmd)
+	skillsCmd.AddCommand(skillsShowCmd)
+	skillsCmd.AddCommand(skillsRemoveCmd)
 }
 
 // SkillInfo representa la información de un skill
 type SkillInfo struct {
-	Name        string `json:"name"`
-	Category    string `json:"category"`
-	Path        string `json:"path"`
-	Description string `json:"description,omitempty"`
+	ID          string   `json:"id"` // Identificador canónico (source/category/name o `id` del frontmatter)
+	Name        string   `json:"name"`
+	Source      string   `json:"source"` // "local" o el nombre de la carpeta en ~/.kolyn/sources
+	Category    string   `json:"category"`
+	Path        string   `json:"path"`
+	RelPath     string   `json:"rel_path"` // Ruta relativa dentro de su source (ej. backend/go/core.md)
+	Dir         string   `json:"-"`        // Directorio de skills del source (base de RelPath)
+	Description string   `json:"description,omitempty"`
+	Capability  string   `json:"capability,omitempty"`
+	AppliesTo   []string `json:"applies_to,omitempty"`
+	Extends     []string `json:"extends,omitempty"` // IDs de las skills padre (frontmatter `extends`)
+
+	Translations map[string]string `json:"translations,omitempty"` // Idioma -> archivo de la variante
 }
 
 // SkillsJSON estructura para retornar todas las skills
@@ -99,25 +159,66 @@ func getSkillDescriptionFromFile(content string) string {
 	return ""
 }
 
-// getSkillsDirs obtiene todos los directorios donde buscar skills (local y sources)
-func getSkillsDirs() ([]string, error) {
-	homeDir, err := os.UserHomeDir()
+// skillsDir es un directorio donde buscar skills y el nombre estable del source al que pertenece
+type skillsDir struct {
+	Source string
+	Path   string
+}
+
+// getSkillsDirs obtiene todos los directorios donde buscar skills, en orden de precedencia:
+// ~/.kolyn/skills, los sources habilitados (config.json y .kolyn.json) por prioridad y los clones
+// que no están configurados.
+// Cuando dos skills tienen el mismo ID gana la del primer directorio.
+func getSkillsDirs() ([]skillsDir, error) {
+	paths, err := config.GetPaths()
 	if err != nil {
-		return nil, fmt.Errorf("error obteniendo directorio home: %w", err)
+		return nil, err
 	}
 
-	dirs := []string{}
-
 	// 1. Skills locales (~/.kolyn/skills)
-	localSkills := filepath.Join(homeDir, ".kolyn", "skills")
-	dirs = append(dirs, localSkills)
+	dirs := []skillsDir{{Source: localSkillsSource, Path: paths.SkillsDir()}}
+
+	// 2. Sources configurados (~/.kolyn/sources/<name>/<subdir>)
+	sourcesDir := paths.SourcesDir()
+	globalCfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		return nil, err
+	}
+	// Los sources del .kolyn.json del proyecto se suman a los globales
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("error obteniendo directorio actual: %w", err)
+	}
+	effectiveCfg, _, err := loadProjectLayer(globalCfg, cwd)
+	if err != nil {
+		return nil, err
+	}
+	configured := make(map[string]bool)
+	for _, source := range effectiveCfg.SkillsSources {
+		configured[source.Name] = true
+	}
+	for _, source := range effectiveCfg.ActiveSources() {
+		// Los sources locales enlazados son symlinks: se resuelven para poder recorrerlos
+		sourceDir, err := config.SourcePath(sourcesDir, source.Name)
+		if err != nil {
+			continue
+		}
+		cloneDir, err := filepath.EvalSymlinks(sourceDir)
+		if err != nil {
+			continue // Aún no sincronizado
+		}
+		dirs = append(dirs, skillsDir{Source: source.Name, Path: filepath.Join(cloneDir, filepath.FromSlash(source.Subdir))})
+	}
 
-	// 2. Skills sincronizados (~/.kolyn/sources/*)
-	sourcesDir := filepath.Join(homeDir, ".kolyn", "sources")
+	// 3. Otros clones sincronizados (~/.kolyn/sources/*)
 	if entries, err := os.ReadDir(sourcesDir); err == nil {
 		for _, entry := range entries {
-			if entry.IsDir() {
-				dirs = append(dirs, filepath.Join(sourcesDir, entry.Name()))
+			if configured[entry.Name()] {
+				continue
+			}
+			dir, err := filepath.EvalSymlinks(filepath.Join(sourcesDir, entry.Name()))
+			if info, statErr := os.Stat(dir); err == nil && statErr == nil && info.IsDir() {
+				dirs = append(dirs, skillsDir{Source: entry.Name(), Path: dir})
 			}
 		}
 	}
@@ -125,70 +226,192 @@ func getSkillsDirs() ([]string, error) {
 	return dirs, nil
 }
 
-// scanSkills busca todos los skills disponibles
+// skillID construye el identificador canónico source/category/name a partir de la ruta relativa al source
+func skillID(source, relPath string) string {
+	return source + "/" + strings.TrimSuffix(filepath.ToSlash(relPath), ".md")
+}
+
+// scanSkills busca todos los skills disponibles (usa el índice en ~/.kolyn/cache)
 func scanSkills(ctx context.Context) ([]SkillInfo, error) {
-	skillDirs, err := getSkillsDirs()
+	indexed, err := scanSkillIndex(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var allSkills []SkillInfo
+	allSkills := make([]SkillInfo, 0, len(indexed))
+	for _, s := range indexed {
+		allSkills = append(allSkills, s.Info)
+	}
+	return allSkills, nil
+}
+
+// skillFilter son los filtros de kolyn skills list (vacío = sin filtro)
+type skillFilter struct {
+	Source      string
+	Category    string
+	Capability  string
+	ProjectType string
+}
 
-	for _, baseDir := range skillDirs {
-		if _, err := os.Stat(baseDir); os.IsNotExist(err) {
-			continue
+// matches indica si la skill cumple todos los filtros.
+// Las skills sin applies_to (o con "generic") aplican a cualquier tipo de proyecto.
+func (f skillFilter) matches(s SkillInfo) bool {
+	if f.Source != "" && !strings.EqualFold(s.Source, f.Source) {
+		return false
+	}
+	if f.Category != "" {
+		category := strings.ToLower(s.Category)
+		want := strings.ToLower(strings.Trim(f.Category, "/"))
+		if category != want && !strings.HasPrefix(category, want+"/") {
+			return false
 		}
+	}
+	if f.Capability != "" && !strings.EqualFold(s.Capability, f.Capability) {
+		return false
+	}
+	if f.ProjectType != "" && len(s.AppliesTo) > 0 &&
+		!containsString(s.AppliesTo, strings.ToLower(f.ProjectType)) && !containsString(s.AppliesTo, "generic") {
+		return false
+	}
+	return true
+}
 
-		err := filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return nil // Skip errors accessing files
-			}
+// runSkillsListOutput imprime las skills filtradas sin interacción
+func runSkillsListOutput(ctx context.Context, format string, filter skillFilter) error {
+	all, err := scanSkills(ctx)
+	if err != nil {
+		return err
+	}
 
-			// Check context cancellation
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-			}
+	var skills []SkillInfo
+	for _, s := range all {
+		if filter.matches(s) {
+			skills = append(skills, s)
+		}
+	}
 
-			if !d.IsDir() && strings.HasSuffix(d.Name(), ".md") && d.Name() != "README.md" {
-				// Calcular categoría relativa al baseDir
-				relPath, _ := filepath.Rel(baseDir, path)
-				category := filepath.Dir(relPath)
-				if category == "." {
-					category = "root"
-				}
-
-				skillName := strings.TrimSuffix(d.Name(), ".md")
-				contentBytes, err := os.ReadFile(path)
-				if err != nil {
-					return nil // Skip unreadable files
-				}
-
-				allSkills = append(allSkills, SkillInfo{
-					Name:        skillName,
-					Category:    category,
-					Path:        path,
-					Description: getSkillDescriptionFromFile(string(contentBytes)),
-				})
-			}
-			return nil
-		})
-		if err != nil {
-			// Don't fail completely if one dir fails
-			// return nil, fmt.Errorf("error escaneando directorio %s: %w", baseDir, err)
+	switch format {
+	case "json":
+		return runSkillsJSON(skills)
+	case "paths":
+		for _, s := range skills {
+			fmt.Println(s.Path)
 		}
+		return nil
+	case "table", "":
+		printSkillsTable(skills)
+		return nil
+	default:
+		return fmt.Errorf("formato no soportado: %s (usa table, json o paths)", format)
 	}
+}
 
-	return allSkills, nil
+// printSkillsTable imprime las skills en columnas ID, CAPABILITY y DESCRIPCIÓN
+func printSkillsTable(skills []SkillInfo) {
+	if len(skills) == 0 {
+		ui.PrintWarning("No se encontraron skills con esos filtros")
+		return
+	}
+
+	width := len("ID")
+	for _, s := range skills {
+		width = max(width, len(s.ID))
+	}
+
+	fmt.Printf("%-*s  %-10s  %s\n", width, "ID", "CAPABILITY", "DESCRIPCIÓN")
+	for _, s := range skills {
+		capability := s.Capability
+		if capability == "" {
+			capability = "-"
+		}
+		fmt.Printf("%-*s  %-10s  %s\n", width, s.ID, capability, s.Description)
+	}
 }
 
-// runSkillsJSON retorna todas las skills en formato JSON
-func runSkillsJSON(ctx context.Context) error {
+// findSkillByID busca una skill por ID canónico; si no hay coincidencia exacta acepta el nombre
+// o la ruta relativa siempre que identifiquen una sola skill
+func findSkillByID(skills []SkillInfo, query string) (SkillInfo, error) {
+	for _, s := range skills {
+		if s.ID == query {
+			return s, nil
+		}
+	}
+
+	var matches []SkillInfo
+	for _, s := range skills {
+		if s.Name == query || strings.TrimSuffix(s.RelPath, ".md") == query {
+			matches = append(matches, s)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return SkillInfo{}, fmt.Errorf("no se encontró la skill '%s' (usa 'kolyn skills list' para ver los IDs)", query)
+	case 1:
+		return matches[0], nil
+	default:
+		var ids []string
+		for _, m := range matches {
+			ids = append(ids, m.ID)
+		}
+		return SkillInfo{}, fmt.Errorf("'%s' es ambiguo, usa el ID: %s", query, strings.Join(ids, ", "))
+	}
+}
+
+// runSkillsShow imprime el contenido, el frontmatter YAML o el JSON de una skill
+func runSkillsShow(ctx context.Context, query string, onlyFrontmatter, asJSON bool) error {
 	skills, err := scanSkills(ctx)
 	if err != nil {
 		return err
 	}
+	skill, err := findSkillByID(skills, query)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(skill.Path)
+	if err != nil {
+		return fmt.Errorf("error leyendo skill: %w", err)
+	}
+
+	yamlContent, _, fmErr := splitFrontmatter(content)
+
+	if asJSON {
+		// Se decodifica a un mapa para respetar las claves tal como las escribió el autor
+		frontmatter := map[string]interface{}{}
+		if fmErr == nil {
+			if err := yaml.Unmarshal(yamlContent, &frontmatter); err != nil {
+				return fmt.Errorf("frontmatter inválido en %s: %w", skill.Path, err)
+			}
+		}
+		data, err := json.MarshalIndent(struct {
+			SkillInfo
+			Frontmatter map[string]interface{} `json:"frontmatter"`
+		}{skill, frontmatter}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error generando JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if onlyFrontmatter {
+		if fmErr != nil {
+			return fmt.Errorf("%s no tiene frontmatter", skill.ID)
+		}
+		fmt.Println(strings.TrimSpace(string(yamlContent)))
+		return nil
+	}
+
+	fmt.Print(string(content))
+	return nil
+}
+
+// runSkillsJSON imprime las skills en formato JSON
+func runSkillsJSON(skills []SkillInfo) error {
+	if skills == nil {
+		skills = []SkillInfo{}
+	}
 
 	result := SkillsJSON{
 		TotalSkills: len(skills),
@@ -237,7 +460,7 @@ func runSkillsList(ctx context.Context) error {
 		ui.ShowSection("📚 Skills Disponibles")
 
 		for i, skill := range skills {
-			ui.WhiteText.Printf("  %d. %s/%s\n", i+1, skill.Category, skill.Name)
+			ui.WhiteText.Printf("  %d. %s\n", i+1, skill.ID)
 			if skill.Description != "" {
 				ui.Gray.Printf("     %s\n", skill.Description)
 			}
@@ -279,7 +502,7 @@ func runSkillsList(ctx context.Context) error {
 // showSkillOptions muestra opciones para un skill específico
 func showSkillOptions(ctx context.Context, reader *bufio.Reader, skill SkillInfo) error {
 	for {
-		ui.ShowSection(fmt.Sprintf("📄 %s/%s", skill.Category, skill.Name))
+		ui.ShowSection(fmt.Sprintf("📄 %s", skill.ID))
 		ui.Gray.Printf("Ruta: %s\n\n", skill.Path)
 
 		ui.WhiteText.Println("  1. Ver contenido (lectura)")
@@ -352,98 +575,38 @@ func editSkillContent(ctx context.Context, skill SkillInfo) error {
 	return nil
 }
 
-// runSkillsNew crea una nueva skill con plantilla
-func runSkillsNew(ctx context.Context, nameArg string) error {
-	ui.ShowSection("✨ Crear Nueva Skill")
-
-	name := nameArg
-	if name == "" {
-		name = ui.ReadInput("Nombre del skill (ej. flutter-riverpod): ")
-	}
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return fmt.Errorf("el nombre es requerido")
+// runSkillsRemove elimina una skill vendorizada y regenera Agent.md
+func runSkillsRemove(root, name string) error {
+	skill, err := findLocalSkill(root, name)
+	if err != nil {
+		return err
 	}
 
-	// Asegurar extensión .md
-	if !strings.HasSuffix(name, ".md") {
-		name += ".md"
+	if err := removeLocalSkill(root, skill.OriginalPath); err != nil {
+		return fmt.Errorf("error eliminando skill: %w", err)
 	}
+	ui.PrintSuccess("Skill eliminada: %s", skill.LocalPath)
 
-	// Directorio destino: ~/.kolyn/skills/ (Local User Skills)
-	homeDir, err := os.UserHomeDir()
+	lock, err := loadSkillsLock(root)
 	if err != nil {
 		return err
 	}
-	destDir := filepath.Join(homeDir, ".kolyn", "skills")
-	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return err
-	}
-
-	destPath := filepath.Join(destDir, name)
-	if _, err := os.Stat(destPath); err == nil {
-		ui.PrintWarning("El archivo ya existe.")
-		if !ui.AskYesNo("¿Sobrescribir?") {
-			return nil
+	if lock.find(skill.LocalPath) != nil {
+		lock.remove(skill.LocalPath)
+		if err := saveSkillsLock(root, lock); err != nil {
+			return fmt.Errorf("error actualizando %s: %w", skillsLockFile, err)
 		}
 	}
 
-	// Plantilla Estándar 2026
-	template := fmt.Sprintf(`---
-name: %s
-description: Descripción corta del skill...
-agent_rules:
-  - "**Rule 1:** Description of rule 1."
-  - "**Rule 2:** Description of rule 2."
-  - "**Quality:** No prints, clean code."
-applies_to: [generic]
-capability: core
-check:
-  required_deps: []
-  files_exist_any: []
----
-
-# %s
-
-## 1. Overview
-Describe el propósito de esta skill y cuándo debe usarse.
-
-## 2. Core Concepts
-Conceptos fundamentales que el agente debe entender.
-
-## 3. Code Snippets
-Ejemplos de código para copiar/pegar.
-
-### Example 1
-`+"```"+`
-// Code here
-`+"```"+`
-
-## 4. Checklist
-- [ ] Regla 1 cumplida
-- [ ] Regla 2 cumplida
-`, strings.TrimSuffix(name, ".md"), strings.TrimSuffix(name, ".md"))
-
-	if err := os.WriteFile(destPath, []byte(template), 0644); err != nil {
-		return fmt.Errorf("error escribiendo archivo: %w", err)
+	localSkills, err := loadAllLocalSkills(root)
+	if err != nil {
+		return fmt.Errorf("error recargando skills locales: %w", err)
 	}
 
-	ui.PrintSuccess("✅ Skill creada en: %s", destPath)
-	ui.Gray.Println("Ahora puedes editarla con 'kolyn skills list' o tu editor favorito.")
-
-	// Opción de abrir editor inmediatamente
-	if ui.AskYesNo("¿Deseas editarla ahora?") {
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = "vim"
-		}
-		cmd := exec.CommandContext(ctx, editor, destPath)
-		cmd.Stdin = os.Stdin
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
+	if err := GenerateAgentMD(root, detectProjectType(root), localSkills); err != nil {
+		return err
 	}
-
+	ui.Gray.Printf("   Agent.md actualizado. Total skills activas: %d\n", len(localSkills))
 	return nil
 }
 
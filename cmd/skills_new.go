@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillsNewSource   string
+	skillsNewCategory string
+	skillsNewBranch   string
+	skillsNewCommit   bool
+)
+
+var skillsNewCmd = &cobra.Command{
+	Use:   "new [name]",
+	Short: "Crea una nueva skill usando la plantilla estándar",
+	Long: `Crea un archivo markdown con la estructura correcta (Frontmatter + Secciones) en ~/.kolyn/skills/.
+
+Con --source la skill se crea en una rama nueva del repo de skills, lista para abrir un PR. La
+rama vive en un worktree aparte (~/.kolyn/worktrees) para que el clon que usa sync no cambie;
+la skill se valida con el linter y con --commit queda commiteada.
+
+Ejemplo:
+  kolyn skills new go-errors --source github.com-tu-org-skills --category backend/go --commit`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runSkillsNew(cmd.Context(), name, skillsNewOptions{
+			Source:   skillsNewSource,
+			Category: skillsNewCategory,
+			Branch:   skillsNewBranch,
+			Commit:   skillsNewCommit,
+		})
+	},
+}
+
+func init() {
+	skillsNewCmd.Flags().StringVar(&skillsNewSource, "source", "", "Repo de skills donde crearla (carpeta en ~/.kolyn/sources o URL)")
+	skillsNewCmd.Flags().StringVar(&skillsNewCategory, "category", "", "Categoría (subcarpeta) de la skill, ej. backend/go")
+	skillsNewCmd.Flags().StringVar(&skillsNewBranch, "branch", "", "Rama a crear en el source (por defecto skill/<categoría>-<nombre>)")
+	skillsNewCmd.Flags().BoolVar(&skillsNewCommit, "commit", false, "Hace commit de la skill en la rama nueva si pasa el linter")
+	skillsCmd.AddCommand(skillsNewCmd)
+}
+
+// skillsNewOptions indica dónde crear la skill y qué hacer con git
+type skillsNewOptions struct {
+	Source   string
+	Category string
+	Branch   string
+	Commit   bool
+}
+
+// runSkillsNew crea una skill desde la plantilla estándar
+func runSkillsNew(ctx context.Context, nameArg string, opts skillsNewOptions) error {
+	ui.ShowSection("✨ Crear Nueva Skill")
+
+	name := nameArg
+	if name == "" {
+		name = ui.ReadInput("Nombre del skill (ej. flutter-riverpod): ")
+	}
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".md")
+	if name == "" {
+		return fmt.Errorf("el nombre es requerido")
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("el nombre no puede contener '/': usa --category para la carpeta")
+	}
+
+	category := strings.Trim(path.Clean("/"+filepath.ToSlash(opts.Category)), "/")
+	relPath := path.Join(category, name+".md")
+
+	// Directorio destino: ~/.kolyn/skills/ (Local User Skills) o el clon del source
+	baseDir, isRepo, err := skillsNewBaseDir(ctx, opts.Source)
+	if err != nil {
+		return err
+	}
+
+	branch := ""
+	cloneDir := baseDir
+	if isRepo {
+		branch = opts.Branch
+		if branch == "" {
+			branch = "skill/" + strings.ReplaceAll(strings.TrimSuffix(relPath, ".md"), "/", "-")
+		}
+		baseDir, err = addSkillWorktree(ctx, cloneDir, branch)
+		if err != nil {
+			return err
+		}
+		ui.PrintStep(fmt.Sprintf("Rama creada: %s", branch))
+	}
+
+	destPath := filepath.Join(baseDir, filepath.FromSlash(relPath))
+	if _, err := os.Stat(destPath); err == nil {
+		ui.PrintWarning("El archivo ya existe.")
+		if !ui.AskYesNo("¿Sobrescribir?") {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(destPath, []byte(newSkillTemplate(name)), 0644); err != nil {
+		return fmt.Errorf("error escribiendo archivo: %w", err)
+	}
+
+	ui.PrintSuccess("✅ Skill creada en: %s", destPath)
+
+	// Opción de abrir editor inmediatamente
+	if ui.AskYesNo("¿Deseas editarla ahora?") {
+		if err := editSkillContent(ctx, SkillInfo{Path: destPath}); err != nil {
+			return err
+		}
+	} else {
+		ui.Gray.Println("Ahora puedes editarla con 'kolyn skills list -i' o tu editor favorito.")
+	}
+
+	// Validar con el linter antes de proponerla al equipo
+	issues := lintSkillFile(destPath)
+	errorsFound := 0
+	for _, issue := range issues {
+		if issue.Severity == lintError {
+			errorsFound++
+		}
+		ui.Gray.Printf("   %s:%d:%d: %s: %s\n", relPath, issue.Line, issue.Column, issue.Severity, issue.Message)
+	}
+	if len(issues) == 0 {
+		ui.PrintSuccess("Lint OK")
+	}
+
+	if !isRepo {
+		return nil
+	}
+
+	if opts.Commit {
+		if errorsFound > 0 {
+			return fmt.Errorf("la skill tiene %d errores de lint; corrígela y haz commit manualmente", errorsFound)
+		}
+		if _, err := gitOutput(ctx, baseDir, "add", filepath.FromSlash(relPath)); err != nil {
+			return fmt.Errorf("error en git add: %w", err)
+		}
+		if _, err := gitOutput(ctx, baseDir, "commit", "-m", fmt.Sprintf("Add skill %s", strings.TrimSuffix(relPath, ".md"))); err != nil {
+			return fmt.Errorf("error en git commit: %w", err)
+		}
+		ui.PrintSuccess("Commit creado en %s", branch)
+	}
+
+	fmt.Println()
+	ui.Gray.Println("Para proponerla al equipo:")
+	ui.Gray.Printf("  cd %s\n", baseDir)
+	if !opts.Commit {
+		ui.Gray.Printf("  git add %s && git commit -m \"Add skill %s\"\n", relPath, strings.TrimSuffix(relPath, ".md"))
+	}
+	ui.Gray.Printf("  git push -u origin %s\n", branch)
+	ui.Gray.Printf("  git -C %s worktree remove %s   # cuando ya no lo necesites\n", cloneDir, baseDir)
+	return nil
+}
+
+// addSkillWorktree crea la rama en un worktree aparte del clon de dir, así el clon que usa
+// sync se queda en su ref. Devuelve el equivalente de dir dentro del worktree (respeta subdir).
+func addSkillWorktree(ctx context.Context, dir, branch string) (string, error) {
+	top, err := gitOutput(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("error leyendo el repo %s: %w", dir, err)
+	}
+	prefix, err := gitOutput(ctx, dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", fmt.Errorf("error leyendo el repo %s: %w", dir, err)
+	}
+
+	paths, err := config.GetPaths()
+	if err != nil {
+		return "", err
+	}
+	worktree := filepath.Join(paths.DataDir, "worktrees", filepath.Base(top)+"-"+strings.ReplaceAll(branch, "/", "-"))
+	if _, err := gitOutput(ctx, top, "worktree", "add", "-b", branch, worktree, "HEAD"); err != nil {
+		return "", fmt.Errorf("error creando la rama %s en %s: %w", branch, worktree, err)
+	}
+	return filepath.Join(worktree, filepath.FromSlash(prefix)), nil
+}
+
+// skillsNewBaseDir resuelve dónde crear la skill: ~/.kolyn/skills o el clon git de un source
+func skillsNewBaseDir(ctx context.Context, source string) (dir string, isRepo bool, err error) {
+	if source == "" || source == localSkillsSource {
+		paths, err := config.GetPaths()
+		if err != nil {
+			return "", false, err
+		}
+		return paths.SkillsDir(), false, nil
+	}
+
+	dir = findSourceDir(ctx, source)
+	if dir == "" {
+		return "", false, fmt.Errorf("el source '%s' no está sincronizado (ejecuta 'kolyn sync')", source)
+	}
+	if _, err := gitOutput(ctx, dir, "rev-parse", "--git-dir"); err != nil {
+		return "", false, fmt.Errorf("%s no es un repositorio git", dir)
+	}
+	return dir, true, nil
+}
+
+// newSkillTemplate devuelve la Plantilla Estándar 2026
+func newSkillTemplate(name string) string {
+	return fmt.Sprintf(`---
+name: %s
+description: Descripción corta del skill...
+agent_rules:
+  - "**Rule 1:** Description of rule 1."
+  - "**Rule 2:** Description of rule 2."
+  - "**Quality:** No prints, clean code."
+applies_to: [generic]
+capability: core
+check:
+  required_deps: []
+  files_exist_any: []
+---
+
+# %s
+
+## 1. Overview
+Describe el propósito de esta skill y cuándo debe usarse.
+
+## 2. Core Concepts
+Conceptos fundamentales que el agente debe entender.
+
+## 3. Code Snippets
+Ejemplos de código para copiar/pegar.
+
+### Example 1
+`+"```"+`
+// Code here
+`+"```"+`
+
+## 4. Checklist
+- [ ] Regla 1 cumplida
+- [ ] Regla 2 cumplida
+`, name, name)
+}
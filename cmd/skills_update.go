@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+	"github.com/spf13/cobra"
+)
+
+var skillsOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Compara las skills vendorizadas contra los sources sincronizados",
+	Long: `Lee .kolyn/skills.lock y compara cada skill vendorizada con su versión actual
+en ~/.kolyn/skills o ~/.kolyn/sources. Termina con error si hay skills desactualizadas.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := currentProjectRoot()
+		if err != nil {
+			return err
+		}
+		return runSkillsOutdated(cmd.Context(), root)
+	},
+}
+
+var (
+	skillsUpdateForce bool
+	skillsUpdateYes   bool
+)
+
+var skillsUpdateCmd = &cobra.Command{
+	Use:   "update [name...]",
+	Short: "Actualiza las skills vendorizadas desde los sources sincronizados",
+	Long: `Trae la versión actual de las skills desactualizadas mostrando el diff.
+Las skills con cambios locales no se sobrescriben a menos que se use --force.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := currentProjectRoot()
+		if err != nil {
+			return err
+		}
+		return runSkillsUpdate(cmd.Context(), root, args, skillsUpdateForce, skillsUpdateYes)
+	},
+}
+
+func init() {
+	skillsUpdateCmd.Flags().BoolVarP(&skillsUpdateForce, "force", "f", false, "Sobrescribe skills con cambios locales")
+	skillsUpdateCmd.Flags().BoolVarP(&skillsUpdateYes, "yes", "y", false, "Aplica los cambios sin pedir confirmación")
+
+	skillsCmd.AddCommand(skillsOutdatedCmd)
+	skillsCmd.AddCommand(skillsUpdateCmd)
+}
+
+// vendoredSkillStatus resume el estado de una skill vendorizada respecto a su lock y su source
+type vendoredSkillStatus struct {
+	Locked         LockedSkill
+	UpstreamPath   string
+	UpstreamHash   string
+	UpstreamCommit string
+	LocalMissing   bool
+	LocalModified  bool
+	UpstreamGone   bool
+}
+
+// outdated indica si update debe traer la skill: cambió en el source o falta la copia local
+func (s vendoredSkillStatus) outdated() bool {
+	return !s.UpstreamGone && (s.LocalMissing || s.UpstreamHash != s.Locked.Hash)
+}
+
+// collectVendoredStatus evalúa cada entrada del lock contra el archivo local y el source
+func collectVendoredStatus(ctx context.Context, root string, lock *SkillsLock) []vendoredSkillStatus {
+	var results []vendoredSkillStatus
+
+	for _, locked := range lock.Skills {
+		status := vendoredSkillStatus{Locked: locked}
+
+		localHash, err := hashFile(filepath.Join(root, filepath.FromSlash(locked.LocalPath)))
+		if err != nil {
+			status.LocalMissing = true
+		} else {
+			status.LocalModified = localHash != locked.localHash()
+		}
+
+		sourceDir := findSourceDir(ctx, locked.Source)
+		if sourceDir == "" {
+			status.UpstreamGone = true
+			results = append(results, status)
+			continue
+		}
+
+		status.UpstreamPath = filepath.Join(sourceDir, filepath.FromSlash(locked.Path))
+		upstreamHash, err := hashFile(status.UpstreamPath)
+		if err != nil {
+			status.UpstreamGone = true
+		} else {
+			status.UpstreamHash = upstreamHash
+		}
+		if locked.Source != localSkillsSource {
+			status.UpstreamCommit, _ = gitOutput(ctx, sourceDir, "rev-parse", "HEAD")
+		}
+
+		results = append(results, status)
+	}
+	return results
+}
+
+// findSourceDir localiza el directorio sincronizado que corresponde a un source del lock
+func findSourceDir(ctx context.Context, source string) string {
+	dirs, err := getSkillsDirs()
+	if err != nil || len(dirs) == 0 {
+		return ""
+	}
+
+	for _, dir := range dirs {
+		if dir.Source == source || dir.Source == config.SanitizeRepoName(source) {
+			return dir.Path
+		}
+	}
+	for _, dir := range dirs[1:] {
+		if url, err := gitOutput(ctx, dir.Path, "remote", "get-url", "origin"); err == nil && url == source {
+			return dir.Path
+		}
+	}
+	return ""
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	if commit == "" {
+		return "-"
+	}
+	return commit
+}
+
+// runSkillsOutdated lista las skills vendorizadas que difieren de su source
+func runSkillsOutdated(ctx context.Context, root string) error {
+	lock, err := loadSkillsLock(root)
+	if err != nil {
+		return err
+	}
+	if len(lock.Skills) == 0 {
+		ui.PrintInfo("No hay skills registradas en .kolyn/%s. Ejecuta 'kolyn init' primero.", skillsLockFile)
+		return nil
+	}
+
+	ui.ShowSection("📦 Skills Vendorizadas")
+
+	outdated := 0
+	for _, s := range collectVendoredStatus(ctx, root, lock) {
+		var notes []string
+		if s.LocalMissing {
+			notes = append(notes, "falta archivo local, 'kolyn skills update' lo restaura")
+		} else if s.LocalModified {
+			notes = append(notes, "cambios locales")
+		}
+
+		switch {
+		case s.UpstreamGone:
+			ui.YellowText.Printf("  ⚠️  %-40s ya no existe en el source (%s)\n", lockedSkillID(s.Locked), s.Locked.Source)
+		case s.outdated():
+			outdated++
+			ui.WhiteText.Printf("  ⬆️  %-40s %s -> %s\n", lockedSkillID(s.Locked), shortCommit(s.Locked.Commit), shortCommit(s.UpstreamCommit))
+		default:
+			ui.Gray.Printf("  ✅ %-40s al día (%s)\n", lockedSkillID(s.Locked), shortCommit(s.Locked.Commit))
+		}
+
+		if len(notes) > 0 {
+			ui.Gray.Printf("      %s\n", strings.Join(notes, ", "))
+		}
+	}
+	fmt.Println()
+
+	if outdated > 0 {
+		ui.Gray.Println("Ejecuta 'kolyn skills update' para traer las nuevas versiones.")
+		return fmt.Errorf("%d skills desactualizadas", outdated)
+	}
+	ui.PrintSuccess("Todas las skills están al día.")
+	return nil
+}
+
+// runSkillsUpdate copia la versión del source sobre las skills vendorizadas desactualizadas
+func runSkillsUpdate(ctx context.Context, root string, names []string, force, yes bool) error {
+	lock, err := loadSkillsLock(root)
+	if err != nil {
+		return err
+	}
+	if len(lock.Skills) == 0 {
+		ui.PrintInfo("No hay skills registradas en .kolyn/%s. Ejecuta 'kolyn init' primero.", skillsLockFile)
+		return nil
+	}
+
+	wanted, err := resolveLockedSkills(lock, names)
+	if err != nil {
+		return err
+	}
+
+	vars, err := newProjectVars(root, !yes)
+	if err != nil {
+		return err
+	}
+
+	ui.ShowSection("🔄 Actualizando Skills")
+
+	updated := 0
+	skipped := 0
+	for _, s := range collectVendoredStatus(ctx, root, lock) {
+		id := lockedSkillID(s.Locked)
+		if len(wanted) > 0 && !wanted[id] {
+			continue
+		}
+		if !s.outdated() {
+			continue
+		}
+
+		localPath := filepath.Join(root, filepath.FromSlash(s.Locked.LocalPath))
+		ui.WhiteText.Printf("📄 %s (%s -> %s)\n", id, shortCommit(s.Locked.Commit), shortCommit(s.UpstreamCommit))
+
+		if s.LocalModified && !force {
+			ui.PrintWarning("%s tiene cambios locales. Usa --force para sobrescribirla.", s.Locked.LocalPath)
+			skipped++
+			continue
+		}
+
+		content, err := os.ReadFile(s.UpstreamPath)
+		if err != nil {
+			ui.PrintError("Fallo al leer %s: %v", s.UpstreamPath, err)
+			continue
+		}
+		rendered, err := vars.render(content)
+		if err != nil {
+			ui.PrintError("Fallo al renderizar %s: %v", id, err)
+			skipped++
+			continue
+		}
+
+		if !s.LocalMissing {
+			showRenderedSkillDiff(ctx, localPath, s.UpstreamPath, content, rendered)
+		}
+
+		if !yes && !ui.AskYesNo(fmt.Sprintf("¿Actualizar %s?", id)) {
+			skipped++
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(localPath, rendered, 0644); err != nil {
+			ui.PrintError("Fallo al escribir %s: %v", s.Locked.LocalPath, err)
+			continue
+		}
+
+		entry := s.Locked
+		entry.Hash = hashContent(content)
+		entry.RenderedHash = ""
+		if !bytes.Equal(rendered, content) {
+			entry.RenderedHash = hashContent(rendered)
+		}
+		entry.Commit = s.UpstreamCommit
+		entry.ID = id
+		lock.upsert(entry)
+		updated++
+		ui.PrintSuccess("%s actualizada", id)
+	}
+
+	if err := vars.save(); err != nil {
+		ui.PrintWarning(err.Error())
+	}
+
+	if updated > 0 {
+		if err := saveSkillsLock(root, lock); err != nil {
+			return fmt.Errorf("error actualizando %s: %w", skillsLockFile, err)
+		}
+
+		localSkills, err := loadAllLocalSkills(root)
+		if err != nil {
+			return fmt.Errorf("error recargando skills locales: %w", err)
+		}
+		if err := GenerateAgentMD(root, detectProjectType(root), localSkills); err != nil {
+			return err
+		}
+	}
+
+	ui.Separator()
+	ui.Gray.Printf("   Actualizadas: %d, omitidas: %d\n", updated, skipped)
+	if updated == 0 && skipped == 0 {
+		ui.PrintSuccess("Todas las skills están al día.")
+	}
+	return nil
+}
+
+// resolveLockedSkills traduce los nombres pedidos a IDs del lock. Acepta el ID, la ruta local
+// o el nombre corto de la skill; un nombre corto que coincide con varias skills es un error.
+func resolveLockedSkills(lock *SkillsLock, names []string) (map[string]bool, error) {
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		needle := strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(name), "./"), ".md")
+		var exact string
+		var matches []string
+		for _, l := range lock.Skills {
+			id := lockedSkillID(l)
+			local := strings.TrimSuffix(strings.TrimPrefix(l.LocalPath, "./"), ".md")
+			if id == name || local == needle || strings.TrimPrefix(local, ".kolyn/skills/") == needle {
+				exact = id
+				break
+			}
+			if l.Name == needle {
+				matches = append(matches, id)
+			}
+		}
+
+		switch {
+		case exact != "":
+			wanted[exact] = true
+		case len(matches) == 1:
+			wanted[matches[0]] = true
+		case len(matches) == 0:
+			return nil, fmt.Errorf("skill '%s' no está en .kolyn/%s", name, skillsLockFile)
+		default:
+			return nil, fmt.Errorf("'%s' es ambiguo, usa el ID completo: %s", name, strings.Join(matches, ", "))
+		}
+	}
+	return wanted, nil
+}
+
+// showRenderedSkillDiff muestra el diff contra la versión renderizada cuando la skill usa variables
+func showRenderedSkillDiff(ctx context.Context, localPath, upstreamPath string, content, rendered []byte) {
+	if bytes.Equal(content, rendered) {
+		showSkillDiff(ctx, localPath, upstreamPath)
+		return
+	}
+
+	tmp, err := os.CreateTemp("", "kolyn-skill-*.md")
+	if err != nil {
+		showSkillDiff(ctx, localPath, upstreamPath)
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(rendered)
+	tmp.Close()
+	if err != nil {
+		showSkillDiff(ctx, localPath, upstreamPath)
+		return
+	}
+	showSkillDiff(ctx, localPath, tmp.Name())
+}
+
+// showSkillDiff imprime el diff entre la copia vendorizada y el source usando git
+func showSkillDiff(ctx context.Context, localPath, upstreamPath string) {
+	cmd := exec.CommandContext(ctx, "git", "--no-pager", "diff", "--no-index", "--color=auto", localPath, upstreamPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// git diff --no-index termina con código 1 cuando hay diferencias
+	_ = cmd.Run()
+	fmt.Println()
+}
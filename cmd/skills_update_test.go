@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestVendoredSkillStatusOutdated(t *testing.T) {
+	tests := []struct {
+		name   string
+		status vendoredSkillStatus
+		want   bool
+	}{
+		{name: "al día", status: vendoredSkillStatus{Locked: LockedSkill{Hash: "a"}, UpstreamHash: "a"}},
+		{name: "cambió en el source", status: vendoredSkillStatus{Locked: LockedSkill{Hash: "a"}, UpstreamHash: "b"}, want: true},
+		{name: "falta la copia local", status: vendoredSkillStatus{Locked: LockedSkill{Hash: "a"}, UpstreamHash: "a", LocalMissing: true}, want: true},
+		{name: "cambios locales sin cambios en el source", status: vendoredSkillStatus{Locked: LockedSkill{Hash: "a"}, UpstreamHash: "a", LocalModified: true}},
+		{name: "ya no existe en el source", status: vendoredSkillStatus{Locked: LockedSkill{Hash: "a"}, LocalMissing: true, UpstreamGone: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.outdated(); got != tt.want {
+				t.Errorf("outdated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
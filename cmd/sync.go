@@ -2,36 +2,92 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	syncUpdate  bool
+	syncDeepen  bool
+	syncOffline bool
+	syncForce   bool
+	syncMajor   bool
+	syncJobs    int
+)
+
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sincroniza skills (Globales)",
-	Long:  `Descarga y actualiza repositorios de skills definidos en ~/.kolyn/config.json.`,
+	Long: `Descarga y actualiza repositorios de skills definidos en ~/.kolyn/config.json y en el
+.kolyn.json del proyecto (se busca subiendo desde el directorio actual).
+
+Los repos git se clonan superficialmente (--depth 1); "sparse" limita el checkout a ciertas
+carpetas y "full_clone": true descarga el historial completo.
+
+Los sources se sincronizan en paralelo (--jobs) y al final se muestra un resumen; si alguno
+falla el comando termina con código distinto de cero y se siguen usando las skills en caché.
+La fecha y el commit de la última sincronización correcta quedan en ~/.kolyn/sync-state.json;
+init y check avisan cuando un source es más antiguo que "sources_max_age" (7d por defecto).
+Con --offline no se usa la red.
+
+Si un clon de ~/.kolyn/sources tiene cambios locales (ej. una skill editada ahí) sync pregunta
+si guardarlos (stash), descartarlos (reset) o no actualizar ese source (keep); --force descarta
+los cambios y deja el clon igual al remoto.
+
+Un source puede fijarse a una rama, tag o commit con "url#ref" (o {"url": ..., "ref": ...});
+sync deja el clon en ese ref. Con --update los sources fijados a un tag avanzan al tag estable
+más reciente de la misma versión mayor (--major permite cambiar de versión mayor; los
+pre-releases como v2.0.0-rc.1 se ignoran) y config.json (o .kolyn.json) se actualiza.`,
+	SilenceUsage:  true,
+	SilenceErrors: true, // El resumen ya muestra los errores; Execute imprime el mensaje final
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runSyncCommand(cmd.Context())
+		return runSyncCommand(cmd.Context(), syncOptions{Update: syncUpdate, Major: syncMajor, Deepen: syncDeepen, Offline: syncOffline, Force: syncForce, Jobs: syncJobs})
 	},
 }
 
-func runSyncCommand(ctx context.Context) error {
+func init() {
+	syncCmd.Flags().BoolVar(&syncUpdate, "update", false, "Mueve los sources fijados a un tag al tag estable más reciente de su versión mayor")
+	syncCmd.Flags().BoolVar(&syncMajor, "major", false, "Con --update permite pasar a una versión mayor nueva (ej. v1.4.0 -> v2.0.0)")
+	syncCmd.Flags().BoolVar(&syncDeepen, "deepen", false, "Descarga el historial completo si un ref fijado no está en el clon superficial")
+	syncCmd.Flags().BoolVar(&syncOffline, "offline", false, "No usa la red: deja los sources remotos como están en la copia local")
+	syncCmd.Flags().BoolVar(&syncForce, "force", false, "Descarta los cambios locales de los clones (git reset --hard al ref remoto)")
+	syncCmd.Flags().IntVarP(&syncJobs, "jobs", "j", 4, "Número de sources que se sincronizan a la vez")
+}
+
+// syncOptions son las opciones de kolyn sync
+type syncOptions struct {
+	Update  bool
+	Major   bool // Con Update: permite cambiar de versión mayor
+	Deepen  bool
+	Offline bool
+	Force   bool
+	Jobs    int
+
+	lastSync     map[string]SourceSyncState      // Estado previo; solo lectura mientras corren los workers
+	localChanges map[string]localChangesDecision // Qué hacer con los clones modificados a mano
+}
+
+func runSyncCommand(ctx context.Context, opts syncOptions) error {
 	// 1. Cargar config global
-	globalCfg, _ := config.LoadGlobalConfig()
+	globalCfg, err := config.LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
 	if globalCfg != nil {
 		ui.CurrentLanguage = globalCfg.Language
-	}
-
-	var sources []string
-
-	if globalCfg == nil {
+		ui.PrintInfo(ui.GetText("using_global"))
+	} else {
 		// Primera vez que corre: Setup inicial interactivo
 		ui.PrintInfo(ui.GetText("no_config"))
 
@@ -40,93 +96,574 @@ func runSyncCommand(ctx context.Context) error {
 		}
 
 		// Recargar la configuración recién creada
-		globalCfg, err := config.LoadGlobalConfig()
+		var err error
+		globalCfg, err = config.LoadGlobalConfig()
 		if err != nil {
 			return fmt.Errorf("error reloading global config: %w", err)
 		}
 		if globalCfg == nil {
 			return fmt.Errorf("configuration was not saved correctly")
 		}
-		sources = globalCfg.SkillsSources
-	} else {
-		ui.PrintInfo(ui.GetText("using_global"))
-		sources = globalCfg.SkillsSources
 	}
 
+	// 1.1 Sources del proyecto (.kolyn.json): se suman a los globales o los reemplazan por name
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error obteniendo directorio actual: %w", err)
+	}
+	effectiveCfg, projectCfg, err := loadProjectLayer(globalCfg, cwd)
+	if err != nil {
+		return err
+	}
+	if projectCfg != nil && len(projectCfg.SkillsSources) > 0 {
+		ui.PrintInfo(ui.GetText("using_local"))
+	}
+	sources := effectiveCfg.SkillsSources
+
 	ui.ShowSection(ui.GetText("sync_start"))
 
 	// 2. Preparar directorio de sources
-	homeDir, err := os.UserHomeDir()
+	paths, err := config.GetPaths()
 	if err != nil {
-		return fmt.Errorf("error getting home dir: %w", err)
+		return err
 	}
 
-	sourcesBaseDir := filepath.Join(homeDir, ".kolyn", "sources")
+	sourcesBaseDir := paths.SourcesDir()
 	if err := os.MkdirAll(sourcesBaseDir, 0755); err != nil {
 		return fmt.Errorf("error creating sources dir: %w", err)
 	}
 
-	// 3. Procesar fuentes
-	for _, sourceURL := range sources {
-		if err := syncSource(ctx, sourceURL, sourcesBaseDir); err != nil {
-			ui.PrintError("Fallo al sincronizar %s: %v", sourceURL, err)
+	state, err := loadSyncState()
+	if err != nil {
+		ui.PrintWarning("%v", err)
+	}
+	opts.lastSync = state.Sources
+
+	// 2.1 Clones con cambios locales: se decide antes de sincronizar en paralelo
+	if !opts.Offline {
+		opts.localChanges = decideLocalChanges(ctx, sources, sourcesBaseDir, opts)
+	}
+
+	// 3. Procesar fuentes en paralelo (como máximo opts.Jobs a la vez)
+	results := syncSources(ctx, sources, sourcesBaseDir, opts)
+
+	// 3.1 Registrar el resultado; un fallo conserva la fecha y el commit del último éxito
+	now := time.Now()
+	for i, r := range results {
+		switch r.Status {
+		case syncStatusOK, syncStatusFailed:
+			state.record(r, now)
+		}
+		entry, ok := state.Sources[r.Source.Name]
+		switch {
+		case !ok:
+		case r.Status == syncStatusFailed:
+			results[i].Detail += " · caché: " + entry.describeCache(now)
+		case r.Status == syncStatusOffline:
+			results[i].Detail = "caché: " + entry.describeCache(now)
+		}
+	}
+	if err := saveSyncState(state); err != nil {
+		ui.PrintWarning("No se pudo guardar el estado de sincronización: %v", err)
+	}
+
+	// 3.2 --update: guardar los nuevos pins en el archivo que define cada source
+	failed := 0
+	pins := make(map[string]config.SkillSource)
+	for i, r := range results {
+		if r.Status == syncStatusFailed {
+			failed++
+		}
+		if r.Source.Ref != sources[i].Ref {
+			pins[r.Source.Name] = r.Source
 		}
 	}
+	if err := savePins(globalCfg, projectCfg, pins); err != nil {
+		return err
+	}
+
+	// 4. Los sources cambiaron: el índice de skills se reconstruye en el próximo escaneo
+	if err := invalidateSkillIndex(); err != nil {
+		ui.PrintWarning("No se pudo limpiar la caché de skills: %v", err)
+	}
+
+	printSyncSummary(results)
+	fmt.Println()
 
+	if failed > 0 {
+		return fmt.Errorf("%d de %d sources no se pudieron sincronizar", failed, len(results))
+	}
 	ui.PrintSuccess(ui.GetText("sync_success"))
 
 	return nil
 }
 
-func syncSource(ctx context.Context, url, baseDir string) error {
-	folderName := sanitizeRepoName(url)
-	targetDir := filepath.Join(baseDir, folderName)
+// savePins guarda los refs que movió --update: los sources del proyecto en su .kolyn.json
+// y el resto en ~/.kolyn/config.json
+func savePins(globalCfg *config.GlobalConfig, projectCfg *config.ProjectConfig, pins map[string]config.SkillSource) error {
+	if len(pins) == 0 {
+		return nil
+	}
+
+	if projectCfg != nil && applyPins(projectCfg.SkillsSources, pins, true) {
+		if err := config.SaveProjectConfig(projectCfg.Root, projectCfg); err != nil {
+			return fmt.Errorf("error guardando %s: %w", config.ProjectConfigFile, err)
+		}
+		ui.PrintInfo("Pins actualizados en %s", config.GetProjectConfigPath(projectCfg.Root))
+	}
+	if applyPins(globalCfg.SkillsSources, pins, false) {
+		if err := config.SaveGlobalConfig(globalCfg); err != nil {
+			return fmt.Errorf("error guardando configuración: %w", err)
+		}
+		path, _ := config.GetGlobalConfigPath()
+		ui.PrintInfo("Pins actualizados en %s", config.DisplayPath(path))
+	}
+	return nil
+}
 
-	if _, err := os.Stat(targetDir); err == nil {
-		// Update
-		ui.PrintStep(ui.GetText("updating_skills", folderName))
-		cmd := exec.CommandContext(ctx, "git", "pull")
-		cmd.Dir = targetDir
-		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0") // Prevent hanging
+// applyPins copia los refs nuevos a los sources de un archivo; fromProject indica de cuál se trata
+func applyPins(sources []config.SkillSource, pins map[string]config.SkillSource, fromProject bool) bool {
+	changed := false
+	for i, s := range sources {
+		if pin, ok := pins[s.Name]; ok && (pin.ProjectRoot != "") == fromProject {
+			sources[i].Ref = pin.Ref
+			changed = true
+		}
+	}
+	return changed
+}
 
-		output, err := cmd.CombinedOutput()
-		outputStr := string(output)
+// syncSources sincroniza los sources habilitados con un pool de opts.Jobs workers.
+// Devuelve un resultado por source, en el mismo orden que config.json.
+func syncSources(ctx context.Context, sources []config.SkillSource, baseDir string, opts syncOptions) []syncResult {
+	progress := newSyncProgress(sources)
+	results := make([]syncResult, len(sources))
 
-		if err != nil {
-			if strings.Contains(outputStr, "Permission denied") ||
-				strings.Contains(outputStr, "Authentication failed") {
-				return fmt.Errorf("\n❌ %s\n%s", ui.GetText("repo_access_error"), outputStr)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(1, min(opts.Jobs, len(sources))); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = syncSourceWithProgress(ctx, sources[i], baseDir, opts, progress.source(sources[i].Name))
 			}
-			return fmt.Errorf("git pull failed: %s (%w)", outputStr, err)
+		}()
+	}
+
+	for i, source := range sources {
+		if !source.IsEnabled() {
+			results[i] = syncResult{Source: source, Status: syncStatusSkipped, Detail: "deshabilitado"}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// syncSourceWithProgress sincroniza un source y arma su fila del resumen
+func syncSourceWithProgress(ctx context.Context, source config.SkillSource, baseDir string, opts syncOptions, log sourceLog) syncResult {
+	start := time.Now()
+	synced, err := syncSource(ctx, source, baseDir, opts, log)
+	result := syncResult{Source: synced, Status: syncStatusOK, Elapsed: time.Since(start), Err: err}
+	switch {
+	case errors.Is(err, errSyncOffline):
+		result.Status = syncStatusOffline
+		result.Err = nil
+	case errors.Is(err, errKeptLocalChanges):
+		result.Status = syncStatusSkipped
+		result.Err = nil
+		result.Detail = "cambios locales: " + opts.localChanges[source.Name].Changes.String()
+		log.done(result.Elapsed, result.Status, nil)
+		return result
+	}
+	log.done(result.Elapsed, result.Status, result.Err)
+
+	if result.Err != nil {
+		result.Status = syncStatusFailed
+		result.Detail = errorDetail(err)
+		return result
+	}
+
+	targetDir, _ := config.SourcePath(baseDir, source.Name) // Ya validado en syncSourceClone
+	switch {
+	case source.Kind() == config.SourceDir && source.Mode == config.SourceModeCopy:
+		result.Detail = "copia"
+	case source.Kind() == config.SourceDir:
+		result.Detail = "enlace"
+	case source.Kind() == config.SourceArchive:
+		result.Detail = "extraído"
+	default:
+		result.Commit, _ = gitOutput(ctx, targetDir, "rev-parse", "HEAD")
+		commit := shortCommit(result.Commit)
+		result.Detail = commit
+		if synced.Ref != "" && !strings.HasPrefix(synced.Ref, commit) {
+			result.Detail = synced.Ref + " @ " + commit
+		}
+	}
+	return result
+}
+
+// syncSource clona o actualiza un source y, si está fijado, lo deja en su ref.
+// Con update un pin a tag avanza al tag más reciente; devuelve el source con el ref final.
+func syncSource(ctx context.Context, source config.SkillSource, baseDir string, opts syncOptions, log sourceLog) (config.SkillSource, error) {
+	synced, err := syncSourceClone(ctx, source, baseDir, opts, log)
+	var accessErr *repoAccessError
+	if errors.As(err, &accessErr) && source.Auth != "" {
+		err = fmt.Errorf("%w\n💡 %s", err, source.Auth)
+	}
+	if err != nil {
+		return source, err
+	}
+
+	if source.Subdir != "" {
+		if dir, err := config.SourcePath(baseDir, source.Name); err == nil && !exists(filepath.Join(dir, filepath.FromSlash(source.Subdir))) {
+			log.warn("El subdir '%s' no existe", source.Subdir)
+		}
+	}
+	return synced, nil
+}
+
+func syncSourceClone(ctx context.Context, source config.SkillSource, baseDir string, opts syncOptions, log sourceLog) (config.SkillSource, error) {
+	// Todo lo que sync escribe o borra queda dentro de sources/<name>
+	folderName := source.Name
+	targetDir, err := config.SourcePath(baseDir, folderName)
+	if err != nil {
+		return source, err
+	}
+
+	if source.Kind() != config.SourceGit && source.Ref != "" {
+		log.warn("No es un repo git: se ignora ref '%s'", source.Ref)
+	}
+	if opts.Offline && source.IsRemote() {
+		return source, useOfflineCopy(ctx, source, targetDir, opts.lastSync[source.Name])
+	}
+	switch source.Kind() {
+	case config.SourceDir:
+		return source, syncLocalDir(source, targetDir, log)
+	case config.SourceArchive:
+		return source, syncArchive(ctx, source, targetDir, log)
+	}
+
+	_, statErr := os.Stat(targetDir)
+	exists := statErr == nil
+	decision := opts.localChanges[source.Name]
+	if exists {
+		log.step("%s", ui.GetText("updating_skills", folderName))
+		if err := applyLocalChangesDecision(ctx, targetDir, decision, log); err != nil {
+			return source, err
 		}
 	} else {
-		// Clone
-		ui.PrintStep(ui.GetText("installing_skills", url))
-		cmd := exec.CommandContext(ctx, "git", "clone", url, targetDir)
-		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0") // Prevent hanging
+		log.step("%s", ui.GetText("installing_skills", source.URL))
+		if err := cloneSource(ctx, source, targetDir); err != nil {
+			return source, err
+		}
+	}
 
-		output, err := cmd.CombinedOutput()
-		outputStr := string(output)
+	if err := applySparseCheckout(ctx, source, targetDir, log); err != nil {
+		return source, err
+	}
+
+	if source.Ref == "" {
+		if !exists {
+			return source, nil
+		}
+		// Un clon que estuvo fijado queda con HEAD separado: volver a la rama por defecto
+		if err := checkoutDefaultBranch(ctx, targetDir); err != nil {
+			return source, err
+		}
+		return source, updateBranch(ctx, targetDir, opts.Force || decision.Action != "")
+	}
 
+	if opts.Update {
+		latest, err := latestTag(ctx, targetDir, source.Ref, opts.Major)
 		if err != nil {
-			if strings.Contains(outputStr, "Permission denied") ||
-				strings.Contains(outputStr, "Authentication failed") ||
-				strings.Contains(outputStr, "could not read Username") {
-				return fmt.Errorf("\n❌ %s\n%s", ui.GetText("repo_access_error"), outputStr)
+			return source, err
+		}
+		if latest != "" && latest != source.Ref {
+			log.info("⬆️  %s -> %s", source.Ref, latest)
+			source.Ref = latest
+		}
+	}
+
+	commit, err := fetchRef(ctx, source, targetDir, opts.Deepen, log)
+	if err != nil {
+		return source, err
+	}
+	return source, checkoutCommit(ctx, targetDir, source.Ref, commit, log)
+}
+
+// updateBranch trae la rama actual del remoto. Solo avanza en fast-forward para no crear
+// merges en el clon; con hard el clon queda igual al remoto aunque haya divergido.
+func updateBranch(ctx context.Context, dir string, hard bool) error {
+	if !hard {
+		err := gitNetwork(ctx, dir, "git pull", "pull", "--ff-only")
+		if err != nil && strings.Contains(err.Error(), "fast-forward") {
+			return fmt.Errorf("el clon divergió del remoto; usa 'kolyn sync --force' para dejarlo igual al remoto: %w", err)
+		}
+		return err
+	}
+
+	if err := gitNetwork(ctx, dir, "git fetch", "fetch", "origin"); err != nil {
+		return err
+	}
+	if _, err := gitOutput(ctx, dir, "reset", "--hard", "--quiet", "@{u}"); err != nil {
+		return fmt.Errorf("git reset failed: %w", err)
+	}
+	return nil
+}
+
+// errSyncOffline indica que un source remoto se dejó como estaba por --offline
+var errSyncOffline = errors.New("sin conexión")
+
+// useOfflineCopy deja un source remoto en su copia local sin usar la red; si está fijado
+// y el ref ya está descargado (o fue el último sincronizado), lo aplica
+func useOfflineCopy(ctx context.Context, source config.SkillSource, targetDir string, last SourceSyncState) error {
+	if _, err := os.Stat(targetDir); err != nil {
+		return fmt.Errorf("no hay copia local de '%s'; ejecuta 'kolyn sync' con conexión", source.Name)
+	}
+	if source.Kind() == config.SourceGit && source.Ref != "" {
+		commit := resolveLocalRef(ctx, targetDir, source.Ref)
+		if commit == "" && last.Ref == source.Ref && last.Commit != "" {
+			// Los tags de un clon superficial no se guardan localmente: usar el commit registrado
+			if _, err := gitOutput(ctx, targetDir, "cat-file", "-e", last.Commit+"^{commit}"); err == nil {
+				commit = last.Commit
 			}
-			return fmt.Errorf("git clone failed: %s (%w)", outputStr, err)
+		}
+		if commit == "" {
+			return fmt.Errorf("el ref '%s' no está en la copia local; ejecuta 'kolyn sync' con conexión", source.Ref)
+		}
+		if _, err := gitOutput(ctx, targetDir, "checkout", "--quiet", "--detach", commit); err != nil {
+			return fmt.Errorf("git checkout %s failed: %w", source.Ref, err)
+		}
+	}
+	return errSyncOffline
+}
+
+// cloneSource clona el repo; por defecto superficial (--depth 1) y, con sparse, sin descargar
+// los archivos fuera de las carpetas configuradas
+func cloneSource(ctx context.Context, source config.SkillSource, targetDir string) error {
+	args := []string{"clone"}
+	if !source.FullClone {
+		args = append(args, "--depth", "1")
+	}
+	if len(source.Sparse) > 0 {
+		args = append(args, "--filter=blob:none", "--sparse")
+	}
+	args = append(args, source.URL, targetDir)
+	return gitNetwork(ctx, "", "git clone", args...)
+}
+
+// applySparseCheckout limita el checkout a las carpetas de "sparse" (o lo desactiva si se quitó)
+func applySparseCheckout(ctx context.Context, source config.SkillSource, dir string, log sourceLog) error {
+	if len(source.Sparse) > 0 {
+		args := append([]string{"sparse-checkout", "set", "--cone"}, source.Sparse...)
+		if _, err := gitOutput(ctx, dir, args...); err != nil {
+			return fmt.Errorf("git sparse-checkout failed: %w", err)
+		}
+		log.info("Sparse: %s", strings.Join(source.Sparse, ", "))
+		return nil
+	}
+
+	if enabled, _ := gitOutput(ctx, dir, "config", "--bool", "core.sparseCheckout"); enabled == "true" {
+		if _, err := gitOutput(ctx, dir, "sparse-checkout", "disable"); err != nil {
+			return fmt.Errorf("git sparse-checkout disable failed: %w", err)
 		}
 	}
 	return nil
 }
 
-func sanitizeRepoName(url string) string {
-	name := url
-	name = strings.TrimPrefix(name, "https://")
-	name = strings.TrimPrefix(name, "http://")
-	name = strings.TrimPrefix(name, "git@")
-	name = strings.TrimSuffix(name, ".git")
-	name = strings.ReplaceAll(name, ":", "/")
-	name = strings.ReplaceAll(name, "/", "-")
-	return name
+// fetchRef trae el ref fijado y devuelve su commit. En un clon superficial solo se descarga ese
+// ref; si no es alcanzable (ej. un commit antiguo) se profundiza el clon cuando deepen es true.
+func fetchRef(ctx context.Context, source config.SkillSource, dir string, deepen bool, log sourceLog) (string, error) {
+	shallow, _ := gitOutput(ctx, dir, "rev-parse", "--is-shallow-repository")
+	args := []string{"fetch", "origin", source.Ref}
+	switch {
+	case shallow == "true" && source.FullClone:
+		args = []string{"fetch", "--unshallow", "--tags", "origin", source.Ref}
+	case shallow == "true":
+		args = []string{"fetch", "--depth", "1", "origin", source.Ref}
+	}
+
+	fetchErr := gitNetwork(ctx, dir, "git fetch", args...)
+	if fetchErr == nil {
+		if commit, err := gitOutput(ctx, dir, "rev-parse", "--verify", "--quiet", "FETCH_HEAD^{commit}"); err == nil {
+			return commit, nil
+		}
+	}
+	var accessErr *repoAccessError
+	if errors.As(fetchErr, &accessErr) {
+		return "", fetchErr
+	}
+
+	// El servidor no entrega commits sueltos por SHA: buscarlo en lo ya descargado
+	if commit := resolveLocalRef(ctx, dir, source.Ref); commit != "" {
+		return commit, nil
+	}
+
+	if shallow, _ = gitOutput(ctx, dir, "rev-parse", "--is-shallow-repository"); shallow != "true" {
+		return "", fmt.Errorf("el ref '%s' no existe en el repositorio", source.Ref)
+	}
+	if !deepen {
+		return "", fmt.Errorf("el ref '%s' no es alcanzable en el clon superficial; usa 'kolyn sync --deepen' o \"full_clone\": true", source.Ref)
+	}
+
+	log.step("Profundizando el clon para encontrar %s", source.Ref)
+	if err := gitNetwork(ctx, dir, "git fetch --unshallow", "fetch", "--unshallow", "--tags", "origin"); err != nil {
+		return "", err
+	}
+	if commit := resolveLocalRef(ctx, dir, source.Ref); commit != "" {
+		return commit, nil
+	}
+	return "", fmt.Errorf("el ref '%s' no existe en el repositorio", source.Ref)
+}
+
+// resolveLocalRef busca el commit de un ref (rama remota, tag o commit) en el clon local
+func resolveLocalRef(ctx context.Context, dir, ref string) string {
+	for _, candidate := range []string{"origin/" + ref, ref} {
+		if commit, err := gitOutput(ctx, dir, "rev-parse", "--verify", "--quiet", candidate+"^{commit}"); err == nil {
+			return commit
+		}
+	}
+	return ""
+}
+
+// repoAccessError indica que git no pudo autenticarse contra el repositorio
+type repoAccessError struct {
+	output string
+}
+
+func (e *repoAccessError) Error() string {
+	return fmt.Sprintf("\n❌ %s\n%s", ui.GetText("repo_access_error"), e.output)
+}
+
+// gitNetwork ejecuta un comando git que usa la red, traduciendo los errores de autenticación
+func gitNetwork(ctx context.Context, dir, op string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0") // Prevent hanging
+
+	output, err := cmd.CombinedOutput()
+	outputStr := string(output)
+
+	if err != nil {
+		if strings.Contains(outputStr, "Permission denied") ||
+			strings.Contains(outputStr, "Authentication failed") ||
+			strings.Contains(outputStr, "could not read Username") {
+			return &repoAccessError{output: outputStr}
+		}
+		return fmt.Errorf("%s failed: %s (%w)", op, outputStr, err)
+	}
+	return nil
+}
+
+// checkoutCommit deja el clon en el commit del ref con HEAD separado
+func checkoutCommit(ctx context.Context, dir, ref, commit string, log sourceLog) error {
+	if _, err := gitOutput(ctx, dir, "checkout", "--quiet", "--detach", commit); err != nil {
+		return fmt.Errorf("git checkout %s failed: %w", ref, err)
+	}
+	log.info("📌 %s @ %s", ref, shortCommit(commit))
+	return nil
+}
+
+// checkoutDefaultBranch vuelve a la rama por defecto si el clon tiene HEAD separado
+func checkoutDefaultBranch(ctx context.Context, dir string) error {
+	if _, err := gitOutput(ctx, dir, "symbolic-ref", "--quiet", "HEAD"); err == nil {
+		return nil // Ya está en una rama
+	}
+
+	remoteHead, err := gitOutput(ctx, dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if err != nil {
+		return fmt.Errorf("no se pudo determinar la rama por defecto: %w", err)
+	}
+	branch := strings.TrimPrefix(remoteHead, "origin/")
+	if _, err := gitOutput(ctx, dir, "checkout", "--quiet", branch); err != nil {
+		return fmt.Errorf("git checkout %s failed: %w", branch, err)
+	}
+	return nil
+}
+
+// latestTag devuelve el tag estable más reciente del remoto (orden de versión) si ref es un tag
+// de versión; "" si no aplica. Salvo con allowMajor, solo considera tags de la misma versión
+// mayor que ref. Se consulta con ls-remote porque un clon superficial no tiene los tags.
+func latestTag(ctx context.Context, dir, ref string, allowMajor bool) (string, error) {
+	out, err := gitOutput(ctx, dir, "ls-remote", "--tags", "--refs", "--sort=-v:refname", "origin")
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote failed: %w", err)
+	}
+
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		if _, tagRef, ok := strings.Cut(line, "\t"); ok {
+			tags = append(tags, strings.TrimPrefix(tagRef, "refs/tags/"))
+		}
+	}
+	if len(tags) == 0 || !containsString(tags, ref) {
+		return "", nil
+	}
+	return pickLatestTag(tags, ref, allowMajor), nil
+}
+
+// pickLatestTag elige el primer tag de versión estable de tags (ordenados de mayor a menor)
+// con la misma versión mayor que ref, o cualquiera con allowMajor. Nunca devuelve un tag
+// anterior a ref.
+func pickLatestTag(tags []string, ref string, allowMajor bool) string {
+	current, _, ok := parseTagVersion(ref)
+	if !ok {
+		return ""
+	}
+	for _, tag := range tags {
+		if tag == ref {
+			break
+		}
+		major, prerelease, ok := parseTagVersion(tag)
+		if !ok || prerelease {
+			continue
+		}
+		if allowMajor || major == current {
+			return tag
+		}
+	}
+	return ""
+}
+
+// parseTagVersion interpreta tags tipo "v1.2.3" o "1.2": devuelve la versión mayor y si es un
+// pre-release ("v2.0.0-rc.1"); ok es false si el tag no es una versión
+func parseTagVersion(tag string) (major int, prerelease bool, ok bool) {
+	version := strings.TrimPrefix(tag, "v")
+	version, _, _ = strings.Cut(version, "+") // Metadatos de build: no cambian la versión
+	version, pre, hasPre := strings.Cut(version, "-")
+	if hasPre && pre == "" {
+		return 0, false, false
+	}
+	for i, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, false, false
+		}
+		if i == 0 {
+			major = n
+		}
+	}
+	return major, hasPre, true
+}
+
+// gitOutput ejecuta un comando git en dir y devuelve su salida sin espacios
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
 }
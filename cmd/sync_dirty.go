@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+)
+
+// Qué hacer con un clon de ~/.kolyn/sources que tiene cambios locales
+const (
+	localChangesStash = "stash" // Guardar los cambios (git stash / rama de respaldo) y actualizar
+	localChangesReset = "reset" // Descartar los cambios y actualizar
+	localChangesKeep  = "keep"  // No tocar el clon en este sync
+)
+
+// errKeptLocalChanges indica que un source no se actualizó para conservar sus cambios locales
+var errKeptLocalChanges = errors.New("cambios locales conservados")
+
+// localChanges describe las modificaciones hechas a mano en un clon de source
+type localChanges struct {
+	Files   int // Archivos modificados o sin trackear
+	Commits int // Commits locales que no están en el remoto
+}
+
+func (c localChanges) any() bool {
+	return c.Files > 0 || c.Commits > 0
+}
+
+func (c localChanges) String() string {
+	var parts []string
+	if c.Files > 0 {
+		parts = append(parts, fmt.Sprintf("%d archivos modificados", c.Files))
+	}
+	if c.Commits > 0 {
+		parts = append(parts, fmt.Sprintf("%d commits locales", c.Commits))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// localChangesDecision es la acción elegida para un source antes de sincronizar
+type localChangesDecision struct {
+	Action  string
+	Changes localChanges
+}
+
+// detectLocalChanges busca archivos modificados y commits locales en un clon. En una rama se
+// compara contra su upstream; con HEAD separado (source fijado) contra el último commit sincronizado.
+func detectLocalChanges(ctx context.Context, dir string, last SourceSyncState) (localChanges, error) {
+	var changes localChanges
+
+	status, err := gitOutput(ctx, dir, "status", "--porcelain")
+	if err != nil {
+		return changes, fmt.Errorf("git status failed: %w", err)
+	}
+	if status != "" {
+		changes.Files = len(strings.Split(status, "\n"))
+	}
+
+	base := "@{u}"
+	if _, err := gitOutput(ctx, dir, "symbolic-ref", "--quiet", "HEAD"); err != nil {
+		base = last.Commit
+	}
+	if base != "" {
+		if count, err := gitOutput(ctx, dir, "rev-list", "--count", base+"..HEAD"); err == nil {
+			changes.Commits, _ = strconv.Atoi(count)
+		}
+	}
+	return changes, nil
+}
+
+// decideLocalChanges revisa (en secuencia, antes de sincronizar en paralelo) los clones git con
+// cambios locales y pregunta qué hacer. Con force se descartan; sin respuesta se conservan.
+func decideLocalChanges(ctx context.Context, sources []config.SkillSource, baseDir string, opts syncOptions) map[string]localChangesDecision {
+	decisions := make(map[string]localChangesDecision)
+	for _, source := range sources {
+		if !source.IsEnabled() || source.Kind() != config.SourceGit {
+			continue
+		}
+		dir, err := config.SourcePath(baseDir, source.Name)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+			continue
+		}
+
+		changes, err := detectLocalChanges(ctx, dir, opts.lastSync[source.Name])
+		if err != nil || !changes.any() {
+			continue
+		}
+
+		if opts.Force {
+			decisions[source.Name] = localChangesDecision{Action: localChangesReset, Changes: changes}
+			continue
+		}
+
+		ui.PrintWarning("'%s' tiene cambios locales (%s) en %s", source.Name, changes, dir)
+		ui.Gray.Println("   [s] stash: guardarlos (git stash / rama de respaldo) y actualizar")
+		ui.Gray.Println("   [r] reset: descartarlos y actualizar")
+		ui.Gray.Println("   [k] keep:  no actualizar este source (por defecto)")
+
+		action := localChangesKeep
+		switch strings.ToLower(strings.TrimSpace(ui.ReadInput("> "))) {
+		case "s", "stash":
+			action = localChangesStash
+		case "r", "reset":
+			action = localChangesReset
+		}
+		decisions[source.Name] = localChangesDecision{Action: action, Changes: changes}
+	}
+	return decisions
+}
+
+// applyLocalChangesDecision prepara el clon según la decisión: guarda o descarta los cambios.
+// Devuelve errKeptLocalChanges si el source no se debe actualizar.
+func applyLocalChangesDecision(ctx context.Context, dir string, decision localChangesDecision, log sourceLog) error {
+	switch decision.Action {
+	case "":
+		return nil
+	case localChangesKeep:
+		log.warn("Se conservan los cambios locales (%s); no se actualiza", decision.Changes)
+		return errKeptLocalChanges
+	case localChangesStash:
+		stamp := time.Now().Format("20060102-150405")
+		if decision.Changes.Files > 0 {
+			if _, err := gitOutput(ctx, dir, "stash", "push", "--include-untracked", "-m", "kolyn sync "+stamp); err != nil {
+				return fmt.Errorf("git stash failed: %w", err)
+			}
+			log.info("Cambios guardados en git stash (kolyn sync %s)", stamp)
+		}
+		if decision.Changes.Commits > 0 {
+			branch := "kolyn/backup-" + stamp
+			if _, err := gitOutput(ctx, dir, "branch", branch, "HEAD"); err != nil {
+				return fmt.Errorf("git branch %s failed: %w", branch, err)
+			}
+			log.info("Commits locales guardados en la rama %s", branch)
+		}
+	}
+
+	// stash y reset: dejar el árbol limpio; el ref remoto se aplica después
+	if _, err := gitOutput(ctx, dir, "reset", "--hard", "--quiet"); err != nil {
+		return fmt.Errorf("git reset failed: %w", err)
+	}
+	if _, err := gitOutput(ctx, dir, "clean", "-fd", "--quiet"); err != nil {
+		return fmt.Errorf("git clean failed: %w", err)
+	}
+	return nil
+}
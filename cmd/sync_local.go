@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+)
+
+// syncLocalDir enlaza (o copia con mode "copy") un directorio local en ~/.kolyn/sources/<name>.
+// Enlazado es ideal para desarrollar skills: los cambios se ven sin volver a sincronizar.
+func syncLocalDir(source config.SkillSource, targetDir string, log sourceLog) error {
+	srcDir, err := source.LocalPath()
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(srcDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("el directorio %s no existe", srcDir)
+	}
+
+	switch source.Mode {
+	case "", config.SourceModeLink:
+		if current, err := os.Readlink(targetDir); err == nil {
+			if current == srcDir {
+				log.info("Enlazado a %s", srcDir)
+				return nil
+			}
+			if err := os.Remove(targetDir); err != nil {
+				return err
+			}
+		} else if _, err := os.Lstat(targetDir); err == nil {
+			return fmt.Errorf("%s ya existe y no es un enlace; bórralo para enlazar %s", targetDir, srcDir)
+		}
+
+		log.step("Enlazando %s", srcDir)
+		if err := os.Symlink(srcDir, targetDir); err != nil {
+			return fmt.Errorf("error creando enlace: %w", err)
+		}
+		return nil
+
+	case config.SourceModeCopy:
+		log.step("Copiando desde %s", srcDir)
+		return replaceSourceDir(targetDir, false, func(tmpDir string) error {
+			return copyTree(srcDir, tmpDir)
+		})
+
+	default:
+		return fmt.Errorf("mode inválido '%s' (usa %s o %s)", source.Mode, config.SourceModeLink, config.SourceModeCopy)
+	}
+}
+
+// syncArchive descarga (http/https) o lee (file://) un .tar.gz/.tgz/.tar/.zip y lo extrae en
+// ~/.kolyn/sources/<name>. Si el archivo tiene una sola carpeta raíz (ej. skills-main/) se omite.
+func syncArchive(ctx context.Context, source config.SkillSource, targetDir string, log sourceLog) error {
+	archivePath, cleanup, err := fetchArchive(ctx, source, log)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	log.step("Extrayendo %s", path.Base(source.URL))
+	return replaceSourceDir(targetDir, true, func(tmpDir string) error {
+		if strings.HasSuffix(strings.ToLower(source.URL), ".zip") {
+			return extractZip(archivePath, tmpDir)
+		}
+		return extractTar(archivePath, tmpDir)
+	})
+}
+
+// fetchArchive devuelve la ruta local del archivo; los remotos se descargan a un temporal
+func fetchArchive(ctx context.Context, source config.SkillSource, log sourceLog) (string, func(), error) {
+	noop := func() {}
+	if strings.HasPrefix(strings.ToLower(source.URL), "file://") {
+		path, err := source.LocalPath()
+		return path, noop, err
+	}
+
+	log.step("%s", ui.GetText("installing_skills", source.URL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return "", noop, fmt.Errorf("error creando petición HTTP: %w", err)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", noop, fmt.Errorf("error descargando %s: %w", source.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return "", noop, &repoAccessError{output: resp.Status}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", noop, fmt.Errorf("error descargando %s (status: %d)", source.URL, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "kolyn-source-*"+filepath.Ext(source.URL))
+	if err != nil {
+		return "", noop, fmt.Errorf("error creando archivo temporal: %w", err)
+	}
+	cleanup := func() { os.Remove(tmp.Name()) }
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		cleanup()
+		return "", noop, fmt.Errorf("error descargando %s: %w", source.URL, err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("error cerrando archivo temporal: %w", err)
+	}
+	return tmp.Name(), cleanup, nil
+}
+
+// replaceSourceDir llena un directorio temporal junto a targetDir y lo reemplaza al terminar,
+// así un fallo a medias no deja el source vacío. Con stripRoot, si todo vive en una única
+// carpeta raíz se usa esa carpeta.
+func replaceSourceDir(targetDir string, stripRoot bool, fill func(tmpDir string) error) error {
+	tmpDir, err := os.MkdirTemp(filepath.Dir(targetDir), "."+filepath.Base(targetDir)+".*")
+	if err != nil {
+		return fmt.Errorf("error creando directorio temporal: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := fill(tmpDir); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+
+	contentDir := tmpDir
+	if entries, err := os.ReadDir(tmpDir); stripRoot && err == nil && len(entries) == 1 && entries[0].IsDir() {
+		contentDir = filepath.Join(tmpDir, entries[0].Name())
+	}
+
+	if err := os.RemoveAll(targetDir); err != nil {
+		return fmt.Errorf("error limpiando %s: %w", targetDir, err)
+	}
+	return os.Rename(contentDir, targetDir)
+}
+
+// safeArchivePath evita que una entrada del archivo escriba fuera del destino ("zip slip")
+func safeArchivePath(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	if target != destDir && !strings.HasPrefix(target, destDir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("entrada inválida en el archivo: %s", name)
+	}
+	return target, nil
+}
+
+func extractTar(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if lower := strings.ToLower(archivePath); strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("error leyendo %s: %w", archivePath, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error leyendo %s: %w", archivePath, err)
+		}
+
+		target, err := safeArchivePath(destDir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, fs.FileMode(hdr.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractZip(archivePath, destDir string) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("error leyendo %s: %w", archivePath, err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		target, err := safeArchivePath(destDir, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc, f.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArchiveFile(target string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// copyTree copia un directorio de skills (sin .git)
+func copyTree(srcDir, destDir string) error {
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destDir, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return writeArchiveFile(target, in, info.Mode())
+	})
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeArchivePath(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "dest")
+
+	tests := []struct {
+		name    string
+		entry   string
+		want    string
+		wantErr bool
+	}{
+		{name: "archivo", entry: "skills/core.md", want: filepath.Join(dest, "skills", "core.md")},
+		{name: "directorio", entry: "skills/", want: filepath.Join(dest, "skills")},
+		{name: "raíz", entry: "./", want: dest},
+		{name: "punto interno", entry: "skills/./core.md", want: filepath.Join(dest, "skills", "core.md")},
+		{name: "sube y vuelve", entry: "skills/../core.md", want: filepath.Join(dest, "core.md")},
+		{name: "absoluta queda dentro", entry: "/etc/passwd", want: filepath.Join(dest, "etc", "passwd")},
+		{name: "sale del destino", entry: "../evil.md", wantErr: true},
+		{name: "sale desde subcarpeta", entry: "skills/../../evil.md", wantErr: true},
+		{name: "directorio hermano con el mismo prefijo", entry: "../dest-evil/x.md", wantErr: true},
+		{name: "solo ..", entry: "..", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := safeArchivePath(dest, tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractRejectsZipSlip(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive string
+		write   func(path string, entries map[string]string) error
+		extract func(archivePath, destDir string) error
+	}{
+		{name: "tar", archive: "skills.tar", write: writeTestTar, extract: extractTar},
+		{name: "zip", archive: "skills.zip", write: writeTestZip, extract: extractZip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			archive := filepath.Join(dir, tt.archive)
+			if err := tt.write(archive, map[string]string{"../evil.md": "pwned"}); err != nil {
+				t.Fatal(err)
+			}
+			dest := filepath.Join(dir, "dest")
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.extract(archive, dest); err == nil {
+				t.Fatal("se extrajo una entrada fuera del destino")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "evil.md")); !os.IsNotExist(err) {
+				t.Errorf("evil.md escrito fuera del destino: %v", err)
+			}
+		})
+	}
+}
+
+func writeTestTar(path string, entries map[string]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	for name, content := range entries {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func writeTestZip(path string, entries map[string]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+)
+
+// Estados de un source al terminar kolyn sync
+const (
+	syncStatusOK      = "ok"
+	syncStatusFailed  = "error"
+	syncStatusSkipped = "omitido"
+	syncStatusOffline = "offline"
+)
+
+// syncProgress serializa las líneas de estado de los sources que se sincronizan en paralelo
+type syncProgress struct {
+	mu    sync.Mutex
+	width int // Ancho de la columna de nombres
+}
+
+func newSyncProgress(sources []config.SkillSource) *syncProgress {
+	p := &syncProgress{}
+	for _, s := range sources {
+		p.width = max(p.width, len(s.Name))
+	}
+	return p
+}
+
+// source devuelve el logger de un source: cada línea lleva su nombre como prefijo
+func (p *syncProgress) source(name string) sourceLog {
+	return sourceLog{progress: p, name: name}
+}
+
+// sourceLog imprime las líneas de estado de un source sin mezclarse con las de los demás
+type sourceLog struct {
+	progress *syncProgress
+	name     string
+}
+
+func (l sourceLog) line(c *color.Color, icon, format string, args ...interface{}) {
+	l.progress.mu.Lock()
+	defer l.progress.mu.Unlock()
+	ui.Gray.Printf("  %-*s  ", l.progress.width, l.name)
+	c.Printf(icon+" "+format+"\n", args...)
+}
+
+// step informa la operación en curso (clonar, actualizar, extraer...)
+func (l sourceLog) step(format string, args ...interface{}) {
+	l.line(ui.CyanText, "⏳", format, args...)
+}
+
+// info muestra un detalle del resultado (ref fijado, tag nuevo...)
+func (l sourceLog) info(format string, args ...interface{}) {
+	l.line(ui.Gray, "  ", format, args...)
+}
+
+func (l sourceLog) warn(format string, args ...interface{}) {
+	l.line(ui.Warning, "⚠️ ", format, args...)
+}
+
+func (l sourceLog) done(elapsed time.Duration, status string, err error) {
+	switch status {
+	case syncStatusOffline:
+		l.line(ui.Gray, "📴", "sin conexión: se usa la copia local")
+		return
+	case syncStatusSkipped:
+		l.line(ui.Gray, "⏸ ", "omitido")
+		return
+	}
+	if err != nil {
+		l.line(ui.RedText, "❌", "falló (%s)", formatElapsed(elapsed))
+		return
+	}
+	l.line(ui.GreenText, "✅", "listo (%s)", formatElapsed(elapsed))
+}
+
+// syncResult es el resultado de un source para la tabla resumen
+type syncResult struct {
+	Source  config.SkillSource
+	Status  string
+	Commit  string // Commit del clon (solo repos git)
+	Detail  string // ref @ commit, "enlace", "copia"... o el motivo del fallo
+	Elapsed time.Duration
+	Err     error
+}
+
+// printSyncSummary imprime la tabla resumen y el detalle de los errores
+func printSyncSummary(results []syncResult) {
+	width := len("SOURCE")
+	for _, r := range results {
+		width = max(width, len(r.Source.Name))
+	}
+
+	fmt.Println()
+	fmt.Printf("%-*s  %-7s  %-7s  %-6s  %s\n", width, "SOURCE", "TIPO", "ESTADO", "TIEMPO", "DETALLE")
+	for _, r := range results {
+		status := ui.GreenText
+		switch r.Status {
+		case syncStatusFailed:
+			status = ui.RedText
+		case syncStatusSkipped, syncStatusOffline:
+			status = ui.Gray
+		}
+
+		elapsed := "-"
+		if r.Status != syncStatusSkipped {
+			elapsed = formatElapsed(r.Elapsed)
+		}
+
+		fmt.Printf("%-*s  %-7s  ", width, r.Source.Name, r.Source.Kind())
+		status.Printf("%-7s", r.Status)
+		fmt.Printf("  %-6s  %s\n", elapsed, r.Detail)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Println()
+			ui.PrintError("%s (%s):", r.Source.Name, r.Source.URL)
+			fmt.Println(strings.TrimSpace(r.Err.Error()))
+		}
+	}
+}
+
+func formatElapsed(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// errorDetail resume un error en una línea para la columna DETALLE: la primera línea
+// "fatal:" de git si existe, si no la primera línea no vacía
+func errorDetail(err error) string {
+	first := ""
+	for _, line := range strings.Split(err.Error(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "fatal:") {
+			return line
+		}
+		if first == "" {
+			first = line
+		}
+	}
+	return first
+}
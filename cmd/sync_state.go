@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+)
+
+const syncStateFile = "sync-state.json"
+
+// SyncState registra el resultado de la última sincronización de cada source (~/.kolyn/sync-state.json)
+type SyncState struct {
+	Sources map[string]SourceSyncState `json:"sources"` // Por nombre de source
+}
+
+// SourceSyncState es el estado de sincronización de un source
+type SourceSyncState struct {
+	URL         string    `json:"url"`
+	Ref         string    `json:"ref,omitempty"`
+	Commit      string    `json:"commit,omitempty"`
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	LastAttempt time.Time `json:"last_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+func getSyncStatePath() (string, error) {
+	paths, err := config.GetPaths()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(paths.DataDir, syncStateFile), nil
+}
+
+// loadSyncState lee el estado; si no existe (nunca se sincronizó) devuelve uno vacío
+func loadSyncState() (*SyncState, error) {
+	state := &SyncState{Sources: make(map[string]SourceSyncState)}
+
+	path, err := getSyncStatePath()
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return &SyncState{Sources: make(map[string]SourceSyncState)}, fmt.Errorf("error leyendo %s: %w", syncStateFile, err)
+	}
+	if state.Sources == nil {
+		state.Sources = make(map[string]SourceSyncState)
+	}
+	return state, nil
+}
+
+func saveSyncState(state *SyncState) error {
+	path, err := getSyncStatePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// record actualiza el estado de un source con el resultado de kolyn sync.
+// Un fallo conserva el último éxito para saber qué tan vieja es la copia en caché.
+func (s *SyncState) record(r syncResult, now time.Time) {
+	entry := s.Sources[r.Source.Name]
+	entry.URL = r.Source.URL
+	entry.LastAttempt = now
+
+	if r.Err != nil {
+		entry.LastError = errorDetail(r.Err)
+	} else {
+		entry.Ref = r.Source.Ref
+		entry.Commit = r.Commit
+		entry.LastSuccess = now
+		entry.LastError = ""
+	}
+	s.Sources[r.Source.Name] = entry
+}
+
+// formatAge expresa una antigüedad en la unidad más legible (ej. "3d", "5h", "10m")
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// describeCache resume la copia en caché de un source (ej. "sync OK hace 3d, commit abc1234")
+func (e SourceSyncState) describeCache(now time.Time) string {
+	if e.LastSuccess.IsZero() {
+		return "nunca se sincronizó correctamente"
+	}
+	desc := fmt.Sprintf("sync OK hace %s", formatAge(now.Sub(e.LastSuccess)))
+	if e.Commit != "" {
+		desc += ", commit " + shortCommit(e.Commit)
+	}
+	return desc
+}
+
+// warnStaleSources avisa (en init y check) de los sources sincronizados hace más de
+// sources_max_age. Los directorios locales enlazados nunca quedan desactualizados.
+func warnStaleSources(globalCfg *config.GlobalConfig) {
+	if globalCfg == nil {
+		return
+	}
+	maxAge, err := globalCfg.SourcesMaxAge()
+	if err != nil {
+		ui.PrintWarning("%v", err)
+		return
+	}
+	if maxAge == 0 {
+		return
+	}
+
+	state, err := loadSyncState()
+	if err != nil {
+		ui.PrintWarning("No se pudo leer el estado de sincronización: %v", err)
+		return
+	}
+
+	now := time.Now()
+	stale := 0
+	for _, source := range globalCfg.ActiveSources() {
+		if source.Kind() == config.SourceDir && source.Mode != config.SourceModeCopy {
+			continue
+		}
+		entry := state.Sources[source.Name]
+		if !entry.LastSuccess.IsZero() && now.Sub(entry.LastSuccess) <= maxAge {
+			continue
+		}
+
+		stale++
+		ui.PrintWarning("Skills de '%s' desactualizadas: %s", source.Name, entry.describeCache(now))
+		if entry.LastError != "" {
+			ui.Gray.Printf("   Último intento (hace %s): %s\n", formatAge(now.Sub(entry.LastAttempt)), entry.LastError)
+		}
+	}
+	if stale > 0 {
+		ui.Gray.Println("   Ejecuta 'kolyn sync' para actualizarlas.")
+		fmt.Println()
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+)
+
+// El pool sincroniza cada source una sola vez y devuelve los resultados en el orden de config.json,
+// sin importar cuántos workers corran ni en qué orden terminen
+func TestSyncSourcesPool(t *testing.T) {
+	disabled := false
+	type wantResult struct {
+		status string
+		detail string
+	}
+
+	tests := []struct {
+		name string
+		jobs int
+	}{
+		{name: "un worker", jobs: 1},
+		{name: "menos workers que sources", jobs: 2},
+		{name: "más workers que sources", jobs: 16},
+		{name: "jobs inválido usa un worker", jobs: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			baseDir := filepath.Join(tmp, "sources")
+			if err := os.MkdirAll(baseDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			var sources []config.SkillSource
+			var want []wantResult
+			for i := 0; i < 6; i++ {
+				dir := filepath.Join(tmp, fmt.Sprintf("repo-%d", i))
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				source := config.SkillSource{Name: fmt.Sprintf("s%d", i), URL: dir}
+				result := wantResult{status: syncStatusOK, detail: "enlace"}
+				switch i % 3 {
+				case 1:
+					source.Mode = config.SourceModeCopy
+					result.detail = "copia"
+				case 2:
+					source.Enabled = &disabled
+					result = wantResult{status: syncStatusSkipped, detail: "deshabilitado"}
+				}
+				sources = append(sources, source)
+				want = append(want, result)
+			}
+			sources = append(sources, config.SkillSource{Name: "falta", URL: filepath.Join(tmp, "no-existe")})
+			want = append(want, wantResult{status: syncStatusFailed})
+
+			results := syncSources(context.Background(), sources, baseDir, syncOptions{Jobs: tt.jobs})
+			if len(results) != len(sources) {
+				t.Fatalf("got %d results, want %d", len(results), len(sources))
+			}
+			for i, r := range results {
+				if r.Source.Name != sources[i].Name {
+					t.Errorf("results[%d].Source = %s, want %s", i, r.Source.Name, sources[i].Name)
+				}
+				if r.Status != want[i].status {
+					t.Errorf("%s: status = %s, want %s (%v)", sources[i].Name, r.Status, want[i].status, r.Err)
+				}
+				if want[i].detail != "" && r.Detail != want[i].detail {
+					t.Errorf("%s: detail = %q, want %q", sources[i].Name, r.Detail, want[i].detail)
+				}
+				if want[i].status == syncStatusOK && !exists(filepath.Join(baseDir, sources[i].Name)) {
+					t.Errorf("%s no quedó sincronizado", sources[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestPickLatestTag(t *testing.T) {
+	// Como los devuelve git ls-remote --sort=-v:refname: de mayor a menor
+	tags := []string{"v3.0.0-rc.1", "v2.1.0", "v2.0.0", "v1.5.0+build.7", "v1.5.0-beta", "v1.4.0", "release-1", "v1.3.0"}
+
+	tests := []struct {
+		name       string
+		ref        string
+		allowMajor bool
+		want       string
+	}{
+		{name: "misma mayor", ref: "v1.3.0", want: "v1.5.0+build.7"},
+		{name: "con --major", ref: "v1.3.0", allowMajor: true, want: "v2.1.0"},
+		{name: "ya en la última de su mayor", ref: "v2.1.0", want: ""},
+		{name: "los pre-releases no cuentan", ref: "v2.1.0", allowMajor: true, want: ""},
+		{name: "nunca baja de versión", ref: "v1.5.0+build.7", want: ""},
+		{name: "ref que no es versión", ref: "release-1", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickLatestTag(tags, tt.ref, tt.allowMajor); got != tt.want {
+				t.Errorf("pickLatestTag(%s, %v) = %q, want %q", tt.ref, tt.allowMajor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTagVersion(t *testing.T) {
+	tests := []struct {
+		tag            string
+		wantMajor      int
+		wantPrerelease bool
+		wantOK         bool
+	}{
+		{tag: "v1.2.3", wantMajor: 1, wantOK: true},
+		{tag: "2.0", wantMajor: 2, wantOK: true},
+		{tag: "v10", wantMajor: 10, wantOK: true},
+		{tag: "v2.0.0-rc.1", wantMajor: 2, wantPrerelease: true, wantOK: true},
+		{tag: "v1.0.0+build.5", wantMajor: 1, wantOK: true},
+		{tag: "v1.0.0-", wantOK: false},
+		{tag: "v1.x", wantOK: false},
+		{tag: "latest", wantOK: false},
+		{tag: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			major, prerelease, ok := parseTagVersion(tt.tag)
+			if ok != tt.wantOK || (ok && (major != tt.wantMajor || prerelease != tt.wantPrerelease)) {
+				t.Errorf("parseTagVersion(%q) = (%d, %v, %v), want (%d, %v, %v)", tt.tag, major, prerelease, ok, tt.wantMajor, tt.wantPrerelease, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestApplyPins(t *testing.T) {
+	pins := map[string]config.SkillSource{
+		"global":   {Name: "global", Ref: "v1.2.0"},
+		"proyecto": {Name: "proyecto", Ref: "v2.1.0", ProjectRoot: "/repo"},
+	}
+
+	tests := []struct {
+		name        string
+		fromProject bool
+		wantRefs    []string
+		wantChanged bool
+	}{
+		{name: "config global", wantRefs: []string{"v1.2.0", "v2.0.0", "main"}, wantChanged: true},
+		{name: ".kolyn.json", fromProject: true, wantRefs: []string{"v1.0.0", "v2.1.0", "main"}, wantChanged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := []config.SkillSource{
+				{Name: "global", Ref: "v1.0.0"},
+				{Name: "proyecto", Ref: "v2.0.0"},
+				{Name: "otro", Ref: "main"},
+			}
+			changed := applyPins(sources, pins, tt.fromProject)
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			var refs []string
+			for _, s := range sources {
+				refs = append(refs, s.Ref)
+			}
+			if !reflect.DeepEqual(refs, tt.wantRefs) {
+				t.Errorf("refs = %q, want %q", refs, tt.wantRefs)
+			}
+		})
+	}
+
+	if applyPins([]config.SkillSource{{Name: "otro", Ref: "main"}}, pins, false) {
+		t.Error("applyPins cambió un source sin pin")
+	}
+}
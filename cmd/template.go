@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+	"github.com/isai-arellano/kolyn-cli/cmd/ui"
+)
+
+// projectVars resuelve los valores de las variables de plantilla de un proyecto.
+// Prioridad: .kolyn.json > valor detectado > respuesta en kolyn init > default de la skill.
+type projectVars struct {
+	root        string
+	interactive bool
+	detected    map[string]string
+	cfg         *config.ProjectConfig
+	changed     bool
+}
+
+func newProjectVars(root string, interactive bool) (*projectVars, error) {
+	// Se usa el .kolyn.json más cercano (puede estar en un directorio padre) para no crear otro que lo oculte
+	cfg, cfgRoot, err := config.FindProjectConfig(root)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &config.ProjectConfig{}
+		cfgRoot = root
+	}
+	if cfg.Variables == nil {
+		cfg.Variables = make(map[string]string)
+	}
+
+	detected := detectProjectVars(root)
+	if cfg.ProjectName != "" {
+		detected["project_name"] = cfg.ProjectName
+	}
+
+	return &projectVars{
+		root:        cfgRoot,
+		interactive: interactive,
+		detected:    detected,
+		cfg:         cfg,
+	}, nil
+}
+
+// detectProjectVars calcula las variables que no hace falta preguntar
+func detectProjectVars(root string) map[string]string {
+	vars := map[string]string{
+		"project_name": filepath.Base(root),
+		"project_type": detectProjectType(root),
+	}
+
+	for _, pm := range []struct{ lockfile, name string }{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+		{"bun.lockb", "bun"},
+		{"package-lock.json", "npm"},
+	} {
+		if exists(filepath.Join(root, pm.lockfile)) {
+			vars["package_manager"] = pm.name
+			break
+		}
+	}
+
+	if exists(filepath.Join(root, "src")) {
+		vars["src_dir"] = "src"
+	}
+	return vars
+}
+
+// values devuelve los datos para renderizar una skill: las variables detectadas más las declaradas
+func (v *projectVars) values(declared []SkillVariable) (map[string]string, error) {
+	data := make(map[string]string, len(v.detected)+len(declared))
+	for k, val := range v.detected {
+		data[k] = val
+	}
+	for k, val := range v.cfg.Variables {
+		data[k] = val
+	}
+
+	for _, d := range declared {
+		if _, ok := data[d.Name]; ok {
+			continue
+		}
+
+		value := d.Default
+		if v.interactive {
+			prompt := d.Prompt
+			if prompt == "" {
+				prompt = fmt.Sprintf("Valor para %s", d.Name)
+			}
+			if d.Default != "" {
+				prompt += fmt.Sprintf(" [%s]", d.Default)
+			}
+			if answer := strings.TrimSpace(ui.ReadInput(prompt + ": ")); answer != "" {
+				value = answer
+			}
+		}
+		if value == "" {
+			return nil, fmt.Errorf("falta el valor de '%s' (defínelo en %s)", d.Name, config.ProjectConfigFile)
+		}
+
+		// Se guarda para no volver a preguntar en el próximo init/update
+		v.cfg.Variables[d.Name] = value
+		v.changed = true
+		data[d.Name] = value
+	}
+	return data, nil
+}
+
+// templateVarRegex encuentra las variables que usa una plantilla ({{ .nombre }}, {{- .nombre }}, ...)
+var templateVarRegex = regexp.MustCompile(`\{\{-?\s*\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// render aplica las variables a una skill que las declara o que usa alguna variable conocida
+// (las detectadas o las de .kolyn.json) sin declararla; las demás se devuelven intactas para no
+// tocar los {{ }} de otros lenguajes de plantillas (Vue, Jinja, ...)
+func (v *projectVars) render(content []byte) ([]byte, error) {
+	fm, err := parseFrontmatter(content)
+	if err != nil || (len(fm.Variables) == 0 && !v.usesKnownVariable(content)) {
+		return content, nil
+	}
+
+	data, err := v.values(fm.Variables)
+	if err != nil {
+		return nil, err
+	}
+	return renderSkillTemplate(content, data)
+}
+
+// usesKnownVariable indica si el contenido usa una variable detectada o definida en .kolyn.json
+func (v *projectVars) usesKnownVariable(content []byte) bool {
+	for _, m := range templateVarRegex.FindAllSubmatch(content, -1) {
+		name := string(m[1])
+		if _, ok := v.detected[name]; ok {
+			return true
+		}
+		if _, ok := v.cfg.Variables[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// save escribe en .kolyn.json los valores nuevos que se pidieron
+func (v *projectVars) save() error {
+	if !v.changed {
+		return nil
+	}
+	if err := config.SaveProjectConfig(v.root, v.cfg); err != nil {
+		return fmt.Errorf("error guardando %s: %w", config.ProjectConfigFile, err)
+	}
+	v.changed = false
+	return nil
+}
+
+// renderSkillTemplate ejecuta el contenido como text/template; una variable sin valor es un error
+func renderSkillTemplate(content []byte, data map[string]string) ([]byte, error) {
+	tmpl, err := template.New("skill").Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("plantilla inválida: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("error renderizando plantilla: %w", err)
+	}
+	return out.Bytes(), nil
+}
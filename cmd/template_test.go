@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
+)
+
+func TestProjectVarsRender(t *testing.T) {
+	vars := &projectVars{
+		detected: map[string]string{"project_name": "billing", "project_type": "nextjs", "package_manager": "pnpm"},
+		cfg:      &config.ProjectConfig{Variables: map[string]string{"db": "postgres"}},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "sin plantilla",
+			content: "---\nname: core\n---\n# Core\n",
+			want:    "---\nname: core\n---\n# Core\n",
+		},
+		{
+			name:    "variable declarada",
+			content: "---\nname: db\nvariables:\n  - name: db\n---\nUsa {{ .db }}\n",
+			want:    "---\nname: db\nvariables:\n  - name: db\n---\nUsa postgres\n",
+		},
+		{
+			name:    "variable detectada sin declarar",
+			content: "---\nname: core\n---\n# {{ .project_name }} usa {{- .package_manager }}\n",
+			want:    "---\nname: core\n---\n# billing usapnpm\n",
+		},
+		{
+			name:    "variable de .kolyn.json sin declarar",
+			content: "---\nname: db\n---\nBase de datos: {{.db}}\n",
+			want:    "---\nname: db\n---\nBase de datos: postgres\n",
+		},
+		{
+			name:    "llaves de otro lenguaje se conservan",
+			content: "---\nname: vue\n---\n<p>{{ message }}</p> y {{ .item.name }}\n",
+			want:    "---\nname: vue\n---\n<p>{{ message }}</p> y {{ .item.name }}\n",
+		},
+		{
+			name:    "sin frontmatter se conserva",
+			content: "# {{ .project_name }}\n",
+			want:    "# {{ .project_name }}\n",
+		},
+		{
+			name:    "variable declarada sin valor",
+			content: "---\nname: x\nvariables:\n  - name: region\n---\n{{ .region }}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vars.render([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderSkillTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		data    map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "variable", content: "{{ .a }}", data: map[string]string{"a": "1"}, want: "1"},
+		{name: "condicional", content: "{{ if eq .a \"1\" }}uno{{ end }}", data: map[string]string{"a": "1"}, want: "uno"},
+		{name: "variable faltante", content: "{{ .b }}", data: map[string]string{}, wantErr: true},
+		{name: "sintaxis inválida", content: "{{ .a ", data: map[string]string{"a": "1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderSkillTemplate([]byte(tt.content), tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
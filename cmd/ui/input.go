@@ -70,7 +70,7 @@ func GetText(key string, args ...interface{}) string {
 		"sync_start":        "Iniciando sincronización...",
 		"sync_success":      "Sincronización completada exitosamente.",
 		"no_config":         "No se detectó configuración. Iniciando configuración global.",
-		"global_created":    "Configuración global creada en ~/.kolyn/config.json",
+		"global_created":    "Configuración global creada en %s",
 		"using_global":      "Usando configuración global de skills.",
 		"using_local":       "Usando configuración local del proyecto (.kolyn.json).",
 		"installing_skills": "Instalando skills desde: %s",
@@ -105,7 +105,7 @@ func GetText(key string, args ...interface{}) string {
 
 		// Docker Up
 		"docker_up_title":         "🚀 Kolyn Up - Levantar Servicios",
-		"docker_up_no_templates":  "No se encontraron templates en ~/.kolyn/templates/",
+		"docker_up_no_templates":  "No se encontraron templates en %s",
 		"docker_up_select":        "Selecciona un servicio para levantar:\n",
 		"docker_up_port":          "(puerto: %s)",
 		"docker_up_cancel_opt":    "  0. Cancelar",
@@ -132,7 +132,7 @@ func GetText(key string, args ...interface{}) string {
 		"sync_start":        "Starting synchronization...",
 		"sync_success":      "Synchronization completed successfully.",
 		"no_config":         "No configuration detected. Starting global setup.",
-		"global_created":    "Global configuration created at ~/.kolyn/config.json",
+		"global_created":    "Global configuration created at %s",
 		"using_global":      "Using global skills configuration.",
 		"using_local":       "Using local project configuration (.kolyn.json).",
 		"installing_skills": "Installing skills from: %s",
@@ -167,7 +167,7 @@ func GetText(key string, args ...interface{}) string {
 
 		// Docker Up
 		"docker_up_title":         "🚀 Kolyn Up - Lift Services",
-		"docker_up_no_templates":  "No templates found in ~/.kolyn/templates/",
+		"docker_up_no_templates":  "No templates found in %s",
 		"docker_up_select":        "Select a service to lift:\n",
 		"docker_up_port":          "(port: %s)",
 		"docker_up_cancel_opt":    "  0. Cancel",
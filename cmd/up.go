@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/isai-arellano/kolyn-cli/cmd/config"
 	"github.com/isai-arellano/kolyn-cli/cmd/ui"
 	"github.com/spf13/cobra"
 )
@@ -31,11 +32,11 @@ type ComposeTemplate struct {
 }
 
 func getTemplates() ([]ComposeTemplate, error) {
-	homeDir, err := os.UserHomeDir()
+	paths, err := config.GetPaths()
 	if err != nil {
-		return nil, fmt.Errorf("error obteniendo home dir: %w", err)
+		return nil, err
 	}
-	templatesDir := filepath.Join(homeDir, ".kolyn", "templates")
+	templatesDir := paths.TemplatesDir()
 
 	// 1. Inicializar directorio y defaults si no existe
 	if _, err := os.Stat(templatesDir); os.IsNotExist(err) {
@@ -217,7 +218,8 @@ func runDockerUpCommand(ctx context.Context) error {
 	ui.ShowSection(ui.GetText("docker_up_title"))
 
 	if len(templates) == 0 {
-		ui.PrintWarning(ui.GetText("docker_up_no_templates"))
+		paths, _ := config.GetPaths()
+		ui.PrintWarning(ui.GetText("docker_up_no_templates", config.DisplayPath(paths.TemplatesDir())))
 		return nil
 	}
 
@@ -229,8 +231,8 @@ func runDockerUpCommand(ctx context.Context) error {
 	ui.Gray.Println(ui.GetText("docker_up_cancel_opt"))
 	fmt.Println()
 
-	homeDir, _ := os.UserHomeDir()
-	ui.Gray.Printf(ui.GetText("docker_up_tip", filepath.Join(homeDir, ".kolyn", "templates")))
+	paths, _ := config.GetPaths()
+	ui.Gray.Print(ui.GetText("docker_up_tip", config.DisplayPath(paths.TemplatesDir())))
 
 	selection := ui.ReadInput(ui.GetText("docker_up_input"))
 
@@ -255,11 +257,11 @@ func runDockerUpCommand(ctx context.Context) error {
 }
 
 func startService(ctx context.Context, t ComposeTemplate) error {
-	homeDir, err := os.UserHomeDir()
+	paths, err := config.GetPaths()
 	if err != nil {
-		return fmt.Errorf("error obteniendo home dir: %w", err)
+		return err
 	}
-	dockerDir := filepath.Join(homeDir, ".kolyn", "services", t.Service)
+	dockerDir := filepath.Join(paths.ServicesDir(), t.Service)
 
 	if _, err := os.Stat(dockerDir); err == nil {
 		ui.PrintWarning(ui.GetText("docker_up_exists", t.Name, dockerDir))
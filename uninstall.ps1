@@ -2,7 +2,7 @@
 [Console]::OutputEncoding = [System.Text.Encoding]::UTF8
 $Binary = "kolyn"
 $InstallDir = "$env:USERPROFILE\bin"
-$KolynDir = "$env:USERPROFILE\.kolyn"
+$LegacyDir = "$env:USERPROFILE\.kolyn"
 
 # Colores
 $Blue = [ConsoleColor]::Blue
@@ -47,12 +47,57 @@ try {
 
 Write-Host ""
 
+# Resolve Kolyn directories the same way the CLI does (config.GetPaths):
+# KOLYN_HOME, then an existing ~/.kolyn, then XDG_CONFIG_HOME/XDG_DATA_HOME/XDG_CACHE_HOME
+function Get-XdgDir($Value, $Default) {
+    if ($Value -and [System.IO.Path]::IsPathRooted($Value)) {
+        return Join-Path $Value "kolyn"
+    }
+    return $Default
+}
+
+if ($env:KOLYN_HOME) {
+    $ConfigDir = [System.IO.Path]::GetFullPath($env:KOLYN_HOME)
+    $DataDir = $ConfigDir
+    $CacheDir = Join-Path $ConfigDir "cache"
+} elseif (Test-Path $LegacyDir) {
+    $ConfigDir = $LegacyDir
+    $DataDir = $LegacyDir
+    $CacheDir = Join-Path $LegacyDir "cache"
+} else {
+    $ConfigDir = Get-XdgDir $env:XDG_CONFIG_HOME $LegacyDir
+    $DataDir = Get-XdgDir $env:XDG_DATA_HOME $LegacyDir
+    $CacheDir = Get-XdgDir $env:XDG_CACHE_HOME (Join-Path $LegacyDir "cache")
+}
+
+Write-Host "Kolyn directories:"
+Write-Host "  config: $ConfigDir"
+Write-Host "  data:   $DataDir"
+Write-Host "  cache:  $CacheDir"
+Write-Host ""
+
+# Removes Kolyn's own entries only (KOLYN_HOME may point to a shared directory)
+function Remove-KolynItems($Dir, $Names) {
+    foreach ($Name in $Names) {
+        $Item = Join-Path $Dir $Name
+        if (Test-Path $Item) {
+            Remove-Item -Recurse -Force $Item
+        }
+    }
+}
+
+function Remove-IfEmpty($Dir) {
+    if ((Test-Path $Dir) -and -not (Get-ChildItem -Force $Dir)) {
+        Remove-Item -Force $Dir
+    }
+}
+
 # 3. Docker Services
 Write-Host "Do you want to remove Docker services created by Kolyn? [y/N]" -ForegroundColor $Yellow -NoNewline
 $response = Read-Host " "
 
 if ($response -match "^(y|yes|s|si)$") {
-    $ServicesDir = Join-Path $KolynDir "services"
+    $ServicesDir = Join-Path $DataDir "services"
     if (Test-Path $ServicesDir) {
         Write-Host "Removing services in $ServicesDir..."
 
@@ -87,19 +132,25 @@ Write-Host "Do you want to remove configuration files? [y/N]" -ForegroundColor $
 $response = Read-Host " "
 
 if ($response -match "^(y|yes|s|si)$") {
-    if (Test-Path $KolynDir) {
+    if ((Test-Path (Join-Path $ConfigDir "config.json")) -or (Test-Path $DataDir) -or (Test-Path $CacheDir)) {
         Write-Host "Do you want to KEEP your downloaded skills/sources? (Recommended if re-installing) [Y/n]" -ForegroundColor $Yellow -NoNewline
         $keepSkills = Read-Host " "
 
+        Remove-KolynItems $ConfigDir @("config.json")
+        if (Test-Path $CacheDir) {
+            Remove-Item -Recurse -Force $CacheDir
+        }
+        Remove-KolynItems $DataDir @("templates", "services", "sync-state.json")
+
         if ($keepSkills -match "^(n|no)$") {
-            Write-Host "Removing entire .kolyn directory..."
-            Remove-Item -Recurse -Force $KolynDir
+            Write-Host "Removing skills and sources..."
+            Remove-KolynItems $DataDir @("skills", "sources", "worktrees")
+            Remove-IfEmpty $DataDir
+            Remove-IfEmpty $ConfigDir
             Write-Host "All configuration and skills removed!" -ForegroundColor $Green
         } else {
-            Write-Host "Cleaning up configuration but keeping skills..."
-            # Remove everything except 'skills' and 'sources' directories
-            Get-ChildItem -Path $KolynDir | Where-Object { $_.Name -ne "skills" -and $_.Name -ne "sources" } | Remove-Item -Recurse -Force
-            Write-Host "Configuration removed. Skills preserved in .kolyn\skills" -ForegroundColor $Green
+            Remove-IfEmpty $ConfigDir
+            Write-Host "Configuration removed. Skills preserved in $DataDir\skills and $DataDir\sources" -ForegroundColor $Green
         }
     } else {
         Write-Host "No configuration files found." -ForegroundColor $Blue
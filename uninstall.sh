@@ -42,24 +42,65 @@ fi
 
 echo -e "${GREEN}Kolyn binary removed successfully!${NC}\n"
 
+# Resolve Kolyn directories the same way the CLI does (config.GetPaths):
+# KOLYN_HOME, then an existing ~/.kolyn, then XDG_CONFIG_HOME/XDG_DATA_HOME/XDG_CACHE_HOME
+xdg_dir() {
+    case "$1" in
+        /*) echo "$1/kolyn" ;;
+        *) echo "$2" ;;
+    esac
+}
+
+LEGACY_DIR="$HOME/.kolyn"
+if [ -n "$KOLYN_HOME" ]; then
+    case "$KOLYN_HOME" in
+        /*) CONFIG_DIR="$KOLYN_HOME" ;;
+        *) CONFIG_DIR="$PWD/$KOLYN_HOME" ;;
+    esac
+    DATA_DIR="$CONFIG_DIR"
+    CACHE_DIR="$CONFIG_DIR/cache"
+elif [ -d "$LEGACY_DIR" ]; then
+    CONFIG_DIR="$LEGACY_DIR"
+    DATA_DIR="$LEGACY_DIR"
+    CACHE_DIR="$LEGACY_DIR/cache"
+else
+    CONFIG_DIR=$(xdg_dir "$XDG_CONFIG_HOME" "$LEGACY_DIR")
+    DATA_DIR=$(xdg_dir "$XDG_DATA_HOME" "$LEGACY_DIR")
+    CACHE_DIR=$(xdg_dir "$XDG_CACHE_HOME" "$LEGACY_DIR/cache")
+fi
+
+echo -e "Kolyn directories:"
+echo -e "  config: $CONFIG_DIR"
+echo -e "  data:   $DATA_DIR"
+echo -e "  cache:  $CACHE_DIR"
+
+# Removes a directory only if it is empty (KOLYN_HOME may point to a shared directory,
+# so only Kolyn's own files are deleted)
+remove_if_empty() {
+    if [ -d "$1" ]; then
+        rmdir "$1" 2>/dev/null || true
+    fi
+}
+
 # Ask about Docker services
 echo -e "\n${YELLOW}Do you want to remove Docker services created by Kolyn? [y/N]${NC}"
 read -r response
 
 if [[ "$response" =~ ^([yY][eE][sS]|[yY])$ ]]; then
-    if [ -d "$HOME/.kolyn/services" ]; then
-        echo -e "Removing ~/.kolyn/services directory..."
+    SERVICES_DIR="$DATA_DIR/services"
+    if [ -d "$SERVICES_DIR" ]; then
+        echo -e "Removing $SERVICES_DIR directory..."
         
         # Stop all services first
         echo -e "${BLUE}Stopping all services...${NC}"
-        for dir in "$HOME/.kolyn/services"/*; do
+        for dir in "$SERVICES_DIR"/*; do
             if [ -d "$dir" ] && [ -f "$dir/docker-compose.yml" ]; then
                 echo -e "  Stopping $(basename "$dir")..."
                 (cd "$dir" && docker compose down -v 2>/dev/null || true)
             fi
         done
         
-        rm -rf "$HOME/.kolyn/services"
+        rm -rf "$SERVICES_DIR"
         echo -e "${GREEN}Docker services removed!${NC}"
     else
         echo -e "${BLUE}No Docker services found.${NC}"
@@ -71,19 +112,23 @@ echo -e "\n${YELLOW}Do you want to remove configuration files? [y/N]${NC}"
 read -r response
 
 if [[ "$response" =~ ^([yY][eE][sS]|[yY])$ ]]; then
-    if [ -d "$HOME/.kolyn" ]; then
+    if [ -e "$CONFIG_DIR/config.json" ] || [ -d "$DATA_DIR" ] || [ -d "$CACHE_DIR" ]; then
         echo -e "${YELLOW}Do you want to KEEP your downloaded skills/sources? (Recommended if you plan to reinstall) [Y/n]${NC}"
         read -r keep_skills
-        
+
+        rm -f "$CONFIG_DIR/config.json"
+        rm -rf "$CACHE_DIR"
+        rm -rf "$DATA_DIR/templates" "$DATA_DIR/services" "$DATA_DIR/sync-state.json"
+
         if [[ "$keep_skills" =~ ^([nN][oO]|[nN])$ ]]; then
-            echo -e "Removing entire ~/.kolyn directory..."
-            rm -rf "$HOME/.kolyn"
+            echo -e "Removing skills and sources..."
+            rm -rf "$DATA_DIR/skills" "$DATA_DIR/sources" "$DATA_DIR/worktrees"
+            remove_if_empty "$DATA_DIR"
+            remove_if_empty "$CONFIG_DIR"
             echo -e "${GREEN}All configuration and skills removed!${NC}"
         else
-            echo -e "Cleaning up configuration but keeping skills..."
-            # Remove everything except skills and sources
-            find "$HOME/.kolyn" -mindepth 1 -maxdepth 1 ! -name 'skills' ! -name 'sources' -exec rm -rf {} +
-            echo -e "${GREEN}Configuration removed. Skills preserved in ~/.kolyn/skills${NC}"
+            remove_if_empty "$CONFIG_DIR"
+            echo -e "${GREEN}Configuration removed. Skills preserved in $DATA_DIR/skills and $DATA_DIR/sources${NC}"
         fi
     else
         echo -e "${BLUE}No configuration files found.${NC}"